@@ -7,10 +7,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
-	"strings"
 	"sync"
-	"unicode"
-	"unicode/utf8"
+
+	"github.com/goyek/goyek/v3/internal"
 )
 
 const maxTempDirTaskNameLen = 64
@@ -32,12 +31,25 @@ type A struct {
 	name      string
 	output    io.Writer
 	logger    Logger
+	logLevel  LogLevel
 	parallel  bool
+	values    *valueStore
+	matrix    map[string]string // set for matrix tasks
+	secrets   []string          // masked in the output
+	unmasked  io.Writer         // output before masking, used by subtasks
+	mask      *maskWriter       // set if the output is masked
+
+	definedTask    string       // name of the defined task running the action
+	middlewares    []Middleware // used to run subtasks
+	signalParallel func()       // set for subtasks
+	subtasks       *sync.WaitGroup
 
 	mu       *sync.Mutex
 	failed   *bool
 	skipped  *bool
+	exitCode *int
 	cleanups *[]func()
+	attrs    *[]Attr
 }
 
 // Context returns a context that is canceled just before
@@ -79,6 +91,102 @@ func (a *A) Logf(format string, args ...interface{}) {
 	a.logger.Logf(a.output, format, args...)
 }
 
+// Debug is equivalent to [A.Log] for debug messages.
+// It does nothing if the log level is above [LevelDebug].
+func (a *A) Debug(args ...interface{}) {
+	if a.logLevel > LevelDebug {
+		return
+	}
+	if l, ok := a.logger.(interface {
+		Debug(w io.Writer, args ...interface{})
+	}); ok {
+		l.Debug(a.output, args...)
+	} else {
+		a.logger.Log(a.output, args...)
+	}
+}
+
+// Debugf is equivalent to [A.Logf] for debug messages.
+// It does nothing if the log level is above [LevelDebug].
+func (a *A) Debugf(format string, args ...interface{}) {
+	if a.logLevel > LevelDebug {
+		return
+	}
+	if l, ok := a.logger.(interface {
+		Debugf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		l.Debugf(a.output, format, args...)
+	} else {
+		a.logger.Logf(a.output, format, args...)
+	}
+}
+
+// Info is equivalent to [A.Log] for informational messages.
+// It does nothing if the log level is above [LevelInfo].
+func (a *A) Info(args ...interface{}) {
+	if a.logLevel > LevelInfo {
+		return
+	}
+	if l, ok := a.logger.(interface {
+		Info(w io.Writer, args ...interface{})
+	}); ok {
+		l.Info(a.output, args...)
+	} else {
+		a.logger.Log(a.output, args...)
+	}
+}
+
+// Infof is equivalent to [A.Logf] for informational messages.
+// It does nothing if the log level is above [LevelInfo].
+func (a *A) Infof(format string, args ...interface{}) {
+	if a.logLevel > LevelInfo {
+		return
+	}
+	if l, ok := a.logger.(interface {
+		Infof(w io.Writer, format string, args ...interface{})
+	}); ok {
+		l.Infof(a.output, format, args...)
+	} else {
+		a.logger.Logf(a.output, format, args...)
+	}
+}
+
+// Warn is equivalent to [A.Log] for warning messages.
+// It does nothing if the log level is above [LevelWarn].
+func (a *A) Warn(args ...interface{}) {
+	if a.logLevel > LevelWarn {
+		return
+	}
+	if l, ok := a.logger.(interface {
+		Warn(w io.Writer, args ...interface{})
+	}); ok {
+		l.Warn(a.output, args...)
+	} else {
+		a.logger.Log(a.output, args...)
+	}
+}
+
+// Warnf is equivalent to [A.Logf] for warning messages.
+// It does nothing if the log level is above [LevelWarn].
+func (a *A) Warnf(format string, args ...interface{}) {
+	if a.logLevel > LevelWarn {
+		return
+	}
+	if l, ok := a.logger.(interface {
+		Warnf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		l.Warnf(a.output, format, args...)
+	} else {
+		a.logger.Logf(a.output, format, args...)
+	}
+}
+
+// LogLevel returns the minimum level of the messages
+// logged by [A.Debug], [A.Info], [A.Warn], and their variants.
+func (a *A) LogLevel() LogLevel {
+	return a.logLevel
+}
+
 // Error is equivalent to [A.Log] followed by [A.Fail].
 func (a *A) Error(args ...interface{}) {
 	if l, ok := a.logger.(interface {
@@ -105,6 +213,31 @@ func (a *A) Errorf(format string, args ...interface{}) {
 	a.Fail()
 }
 
+// SetAttr sets the task run attribute, such as a coverage percentage
+// or an artifact path, which is reported in [Result.Attrs].
+// Setting an attribute with the same key replaces its value.
+func (a *A) SetAttr(key string, value interface{}) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for i, attr := range *a.attrs {
+		if attr.Key == key {
+			(*a.attrs)[i].Value = value
+			return
+		}
+	}
+	*a.attrs = append(*a.attrs, Attr{Key: key, Value: value})
+}
+
+// Attrs returns the task run attributes set using [A.SetAttr].
+func (a *A) Attrs() []Attr {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if len(*a.attrs) == 0 {
+		return nil
+	}
+	return append([]Attr(nil), *a.attrs...)
+}
+
 // Failed reports whether the function has failed.
 func (a *A) Failed() bool {
 	a.mu.Lock()
@@ -282,10 +415,7 @@ func (a *A) TempDir() string {
 	// Drop unusual characters (such as path separators or
 	// characters interacting with globs) from the directory name to
 	// avoid surprising os.MkdirTemp behavior.
-	name := strings.Map(tempDirMapper, a.Name())
-	if len(name) > maxTempDirTaskNameLen {
-		name = truncateUTF8(name[:maxTempDirTaskNameLen])
-	}
+	name := internal.FileName(a.Name(), maxTempDirTaskNameLen)
 
 	dir, err := os.MkdirTemp("", "goyek-"+name+"-*")
 	if err != nil {
@@ -348,6 +478,7 @@ func (a *A) run(action func(a *A)) (finished bool, panicVal interface{}, panicSt
 	go func() {
 		defer close(ch)
 		defer a.runCleanups(&finished, &panicVal, &panicStack)
+		defer a.waitSubtasks()
 		defer func() {
 			if finished {
 				return
@@ -362,30 +493,6 @@ func (a *A) run(action func(a *A)) (finished bool, panicVal interface{}, panicSt
 	return finished, panicVal, panicStack
 }
 
-func tempDirMapper(r rune) rune {
-	if r < utf8.RuneSelf {
-		const allowed = "!#$%&()+,-.=@^_{}~ "
-		if '0' <= r && r <= '9' ||
-			'a' <= r && r <= 'z' ||
-			'A' <= r && r <= 'Z' {
-			return r
-		}
-		if strings.ContainsRune(allowed, r) {
-			return r
-		}
-	} else if unicode.IsLetter(r) || unicode.IsNumber(r) {
-		return r
-	}
-	return -1
-}
-
-func truncateUTF8(s string) string {
-	for len(s) > 0 && !utf8.ValidString(s) {
-		s = s[:len(s)-1]
-	}
-	return s
-}
-
 func (a *A) runCleanups(finished *bool, panicVal *interface{}, panicStack *[]byte) {
 	// Cancel the context before running cleanup functions,
 	// matching testing.T.Context behavior.
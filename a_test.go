@@ -291,6 +291,43 @@ func TestA_Cleanup(t *testing.T) {
 	assertContains(t, out, "1\n2\n3\n4\n5", "should call cleanup funcs in LIFO order")
 }
 
+func TestA_SetAttr(t *testing.T) {
+	got := goyek.NewRunner(func(a *goyek.A) {
+		a.SetAttr("coverage", 80.1)
+		a.SetAttr("artifact", "dist/app.tar.gz")
+		a.WithContext(context.Background()).SetAttr("coverage", 83.4)
+		a.Cleanup(func() {
+			a.SetAttr("cleanup", true)
+		})
+		a.FailNow()
+	})(goyek.Input{})
+
+	want := []goyek.Attr{
+		{Key: "coverage", Value: 83.4},
+		{Key: "artifact", Value: "dist/app.tar.gz"},
+		{Key: "cleanup", Value: true},
+	}
+	assertEqual(t, got.Attrs, want, "should return attributes in the result")
+}
+
+func TestA_SetExitCode(t *testing.T) {
+	var got int
+	res := goyek.NewRunner(func(a *goyek.A) {
+		a.SetExitCode(4)
+		a.WithContext(context.Background()).SetExitCode(5)
+		got = a.ExitCode()
+	})(goyek.Input{})
+
+	assertEqual(t, got, 5, "should return the exit code")
+	assertEqual(t, res.ExitCode, 5, "should return the exit code in the result")
+}
+
+func TestA_SetExitCode_invalid(t *testing.T) {
+	goyek.NewRunner(func(a *goyek.A) {
+		assertPanics(t, func() { a.SetExitCode(0) }, "should panic for non-positive exit code")
+	})(goyek.Input{})
+}
+
 func TestA_Cleanup_when_action_panics(t *testing.T) {
 	out := &strings.Builder{}
 
@@ -576,12 +613,37 @@ func TestA_uses_Logger_dynamic_interface(t *testing.T) {
 			desc:   "Skipf",
 			action: func(a *goyek.A) { a.Skipf("") },
 		},
+		{
+			desc:   "Debug",
+			action: func(a *goyek.A) { a.Debug() },
+		},
+		{
+			desc:   "Debugf",
+			action: func(a *goyek.A) { a.Debugf("") },
+		},
+		{
+			desc:   "Info",
+			action: func(a *goyek.A) { a.Info() },
+		},
+		{
+			desc:   "Infof",
+			action: func(a *goyek.A) { a.Infof("") },
+		},
+		{
+			desc:   "Warn",
+			action: func(a *goyek.A) { a.Warn() },
+		},
+		{
+			desc:   "Warnf",
+			action: func(a *goyek.A) { a.Warnf("") },
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.desc, func(t *testing.T) {
 			flow := &goyek.Flow{}
 
 			flow.SetOutput(io.Discard)
+			flow.SetLogLevel(goyek.LevelDebug)
 			loggerSpy := &helperLoggerSpy{}
 			flow.SetLogger(loggerSpy)
 			flow.Define(goyek.Task{
@@ -632,10 +694,70 @@ func (l *helperLoggerSpy) Skipf(_ io.Writer, _ string, _ ...interface{}) {
 	l.called = true
 }
 
+func (l *helperLoggerSpy) Debug(_ io.Writer, _ ...interface{}) {
+	l.called = true
+}
+
+func (l *helperLoggerSpy) Debugf(_ io.Writer, _ string, _ ...interface{}) {
+	l.called = true
+}
+
+func (l *helperLoggerSpy) Info(_ io.Writer, _ ...interface{}) {
+	l.called = true
+}
+
+func (l *helperLoggerSpy) Infof(_ io.Writer, _ string, _ ...interface{}) {
+	l.called = true
+}
+
+func (l *helperLoggerSpy) Warn(_ io.Writer, _ ...interface{}) {
+	l.called = true
+}
+
+func (l *helperLoggerSpy) Warnf(_ io.Writer, _ string, _ ...interface{}) {
+	l.called = true
+}
+
 func (l *helperLoggerSpy) Helper() {
 	l.called = true
 }
 
+func TestA_log_levels(t *testing.T) {
+	testCases := []struct {
+		level goyek.LogLevel
+		want  string
+	}{
+		{level: goyek.LevelDebug, want: "debug\ndebug 1\ninfo\ninfo 1\nwarn\nwarn 2\nlog\n"},
+		{level: goyek.LevelInfo, want: "info\ninfo 1\nwarn\nwarn 2\nlog\n"},
+		{level: goyek.LevelWarn, want: "warn\nwarn 2\nlog\n"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.level.String(), func(t *testing.T) {
+			flow := &goyek.Flow{}
+			out := &strings.Builder{}
+			flow.SetOutput(out)
+			flow.SetLogger(goyek.FmtLogger{})
+			flow.SetLogLevel(tc.level)
+			flow.Define(goyek.Task{
+				Name: "task",
+				Action: func(a *goyek.A) {
+					a.Debug("debug")
+					a.Debugf("debug %d", 1)
+					a.Info("info")
+					a.Infof("info %d", 1)
+					a.Warn("warn")
+					a.Warnf("warn %d", 2)
+					a.Log("log")
+				},
+			})
+
+			_ = flow.Execute(context.Background(), []string{"task"})
+
+			assertEqual(t, out.String(), tc.want, "should log messages at or above the level")
+		})
+	}
+}
+
 func TestA_Setenv_parallel_panic(t *testing.T) {
 	out := &strings.Builder{}
 	got := goyek.NewRunner(func(a *goyek.A) {
@@ -2,9 +2,12 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"strings"
 	"time"
 
@@ -20,6 +23,9 @@ const (
 
 const exitCodeInvalid = 2
 
+// stateFile persists the task statuses used by the -rerun-failed and -resume flags.
+const stateFile = ".goyek/state.json"
+
 // Reusable flags used by the build pipeline.
 var (
 	v       = flag.Bool("v", false, "print all tasks and tests as they are run")
@@ -27,6 +33,10 @@ var (
 	longRun = flag.Duration("long-run", time.Minute, "print when a task takes longer")
 	noDeps  = flag.Bool("no-deps", false, "do not process dependencies")
 	skip    = flag.String("skip", "", "skip processing the `comma-separated tasks`")
+	logDir  = flag.String("log-dir", "", "write the output of each task to a log file in the `directory`")
+	watch   = flag.Bool("watch", false, "run the tasks again when the files change")
+	rerun   = flag.Bool("rerun-failed", false, "run only the tasks which failed or did not run last time")
+	resume  = flag.Bool("resume", false, "skip the tasks which passed during the last failed run")
 )
 
 func main() {
@@ -48,7 +58,9 @@ func main() {
 		os.Exit(exitCodeInvalid)
 	}
 
-	goyek.UseExecutor(middleware.ReportFlow)
+	palette := middleware.AutoPalette(os.Stdout)
+	goyek.SetLogger(middleware.ColorLogger(goyek.GetLogger(), palette))
+	goyek.UseExecutor(middleware.ReportFlowColor(palette))
 
 	if *dryRun {
 		*v = true // needed to report the task status
@@ -57,15 +69,20 @@ func main() {
 	if *dryRun {
 		goyek.Use(middleware.DryRun)
 	}
-	goyek.Use(middleware.ReportStatus)
-	if !*v {
+	goyek.Use(middleware.ReportStatusColor(palette))
+	if *logDir != "" {
+		goyek.Use(middleware.TaskLogs(*logDir))
+	}
+	if *v {
+		goyek.SetLogLevel(goyek.LevelDebug)
+	} else {
 		goyek.Use(middleware.SilentNonFailed)
 	}
 	if *longRun > 0 {
-		goyek.Use(middleware.ReportLongRun(*longRun))
+		goyek.Use(middleware.ReportLongRunColor(*longRun, palette))
 	}
 
-	var opts []goyek.Option
+	opts := []goyek.Option{goyek.DumpOnQuit()}
 	if *noDeps {
 		opts = append(opts, goyek.NoDeps())
 	}
@@ -73,8 +90,25 @@ func main() {
 		skippedTasks := strings.Split(*skip, ",")
 		opts = append(opts, goyek.Skip(skippedTasks...))
 	}
+	goyek.SetStateFile(stateFile)
+	if *rerun {
+		opts = append(opts, goyek.RerunFailed())
+	}
+	if *resume {
+		opts = append(opts, goyek.Resume())
+	}
 
 	goyek.SetUsage(usage)
+	if *watch {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		opts = append(opts, goyek.WatchExclude("coverage.*", "*.log"))
+		if err := goyek.Watch(ctx, tasks, []string{dirRoot}, opts...); !errors.Is(err, context.Canceled) {
+			fmt.Fprintln(out, err)
+			os.Exit(exitCodeInvalid) //nolint:gocritic // stop is not needed when exiting
+		}
+		return
+	}
 	goyek.Main(tasks, opts...)
 }
 
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/exec"
 
@@ -10,10 +11,13 @@ import (
 var mdlint = goyek.Define(goyek.Task{
 	Name:  "mdlint",
 	Usage: "markdownlint-cli (uses docker)",
-	Action: func(a *goyek.A) {
+	Condition: func(context.Context) (bool, string) {
 		if _, err := exec.LookPath("docker"); err != nil {
-			a.Skip(err)
+			return false, err.Error()
 		}
+		return true, ""
+	},
+	Action: func(a *goyek.A) {
 		curDir, err := os.Getwd()
 		if err != nil {
 			a.Fatal(err)
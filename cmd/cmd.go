@@ -0,0 +1,302 @@
+// Package cmd provides helpers to run programs from task actions.
+//
+// The programs are run using [goyek.A.Context] and their output is written
+// to [goyek.A.Output]. When the context is canceled, the program
+// is interrupted and killed after a grace period (see [GracePeriod]).
+// On Windows, the program cannot be interrupted, so it is killed immediately.
+//
+// On Unix-like systems, the program is started in a new process group,
+// so that it can be interrupted together with its child processes.
+// The process group is in the background of the terminal, so a program
+// reading from the terminal, for example, to ask for a password,
+// is stopped by SIGTTIN. Use [os/exec] to run such programs.
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// Option configures the program execution.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (fn optionFunc) apply(cfg *config) {
+	fn(cfg)
+}
+
+type config struct {
+	dir       string
+	env       []string
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+	interrupt os.Signal
+	grace     time.Duration
+}
+
+// DefaultGracePeriod is the default time given to the program to exit
+// after it was interrupted.
+const DefaultGracePeriod = 10 * time.Second
+
+// Dir is an option to set the working directory of the program.
+func Dir(dir string) Option {
+	return optionFunc(func(c *config) {
+		c.dir = dir
+	})
+}
+
+// Env is an option to set the environment variable of the program.
+// The program inherits the environment of the current process.
+func Env(key, value string) Option {
+	return optionFunc(func(c *config) {
+		c.env = append(c.env, key+"="+value)
+	})
+}
+
+// Stdin is an option to set the standard input of the program.
+// By default, the program reads from the null device.
+// On Unix-like systems, passing [os.Stdin] connected to a terminal
+// stops the program when it reads from it (see the package documentation).
+func Stdin(r io.Reader) Option {
+	return optionFunc(func(c *config) {
+		c.stdin = r
+	})
+}
+
+// Stdout is an option to set the standard output of the program.
+// By default, [goyek.A.Output] is used.
+func Stdout(w io.Writer) Option {
+	return optionFunc(func(c *config) {
+		c.stdout = w
+	})
+}
+
+// Stderr is an option to set the standard error of the program.
+// By default, [goyek.A.Output] is used.
+func Stderr(w io.Writer) Option {
+	return optionFunc(func(c *config) {
+		c.stderr = w
+	})
+}
+
+// InterruptSignal is an option to set the signal sent to the program
+// when [goyek.A.Context] is canceled.
+// By default, SIGTERM is used on Unix-like systems and [os.Interrupt]
+// on other systems. If the signal cannot be sent, which is always
+// the case on Windows, the program is killed immediately.
+func InterruptSignal(sig os.Signal) Option {
+	return optionFunc(func(c *config) {
+		c.interrupt = sig
+	})
+}
+
+// GracePeriod is an option to set the time given to the program to exit
+// after it was interrupted. When the grace period expires, the program
+// and its child processes are killed and the processes
+// which were still running are logged. By default, [DefaultGracePeriod]
+// is used. If d is zero or negative, the program is killed immediately
+// and the killed processes are logged.
+func GracePeriod(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.grace = d
+	})
+}
+
+// Exec runs the program with the arguments from the command line
+// split using [Split]. It calls [goyek.A.Error] and returns false
+// in case of any problems.
+func Exec(a *goyek.A, cmdLine string, opts ...Option) bool {
+	a.Helper()
+	if err := Run(a, cmdLine, opts...); err != nil {
+		a.Error(err)
+		return false
+	}
+	return true
+}
+
+// ExecArgs runs the program like [Exec]. The first element of args
+// is the program and the rest are its arguments, which are passed
+// as they are, so they do not have to be quoted.
+func ExecArgs(a *goyek.A, args []string, opts ...Option) bool {
+	a.Helper()
+	if err := RunArgs(a, args, opts...); err != nil {
+		a.Error(err)
+		return false
+	}
+	return true
+}
+
+// Output runs the program like [Exec] and returns its standard output.
+func Output(a *goyek.A, cmdLine string, opts ...Option) (string, bool) {
+	a.Helper()
+	sb := &strings.Builder{}
+	opts = append(opts, Stdout(sb))
+	if err := Run(a, cmdLine, opts...); err != nil {
+		a.Error(err)
+		return sb.String(), false
+	}
+	return sb.String(), true
+}
+
+// OutputArgs runs the program like [ExecArgs] and returns its standard output.
+func OutputArgs(a *goyek.A, args []string, opts ...Option) (string, bool) {
+	a.Helper()
+	sb := &strings.Builder{}
+	opts = append(opts, Stdout(sb))
+	if err := RunArgs(a, args, opts...); err != nil {
+		a.Error(err)
+		return sb.String(), false
+	}
+	return sb.String(), true
+}
+
+// Run runs the program with the arguments from the command line
+// split using [Split] and returns the error without failing the task.
+// Use [ExitCode] to get the exit code of the program.
+func Run(a *goyek.A, cmdLine string, opts ...Option) error {
+	a.Helper()
+	args, err := Split(cmdLine)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		return errors.New("empty command line")
+	}
+	return run(a, args, cmdLine, opts)
+}
+
+// RunArgs runs the program like [Run]. The first element of args
+// is the program and the rest are its arguments, which are passed
+// as they are, so they do not have to be quoted.
+func RunArgs(a *goyek.A, args []string, opts ...Option) error {
+	a.Helper()
+	if len(args) == 0 {
+		return errors.New("no program to run")
+	}
+	return run(a, args, join(args), opts)
+}
+
+func run(a *goyek.A, args []string, cmdLine string, opts []Option) error {
+	a.Helper()
+	cfg := &config{
+		interrupt: defaultInterruptSignal,
+		grace:     DefaultGracePeriod,
+	}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
+	if cfg.dir != "" {
+		a.Logf("Exec: %s (in %s)", cmdLine, cfg.dir)
+	} else {
+		a.Logf("Exec: %s", cmdLine)
+	}
+	c := exec.Command(args[0], args[1:]...) //nolint:gosec // running programs is the purpose of the package
+	setProcessGroup(c)
+	c.Dir = cfg.dir
+	if len(cfg.env) > 0 {
+		c.Env = append(os.Environ(), cfg.env...)
+	}
+	c.Stdin = cfg.stdin
+	c.Stdout = cfg.stdout
+	if c.Stdout == nil {
+		c.Stdout = a.Output()
+	}
+	c.Stderr = cfg.stderr
+	if c.Stderr == nil {
+		c.Stderr = a.Output()
+	}
+	if err := c.Start(); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+
+	exited := make(chan struct{})
+	killed := make(chan killResult, 1)
+	go func() {
+		select {
+		case <-exited:
+			killed <- killResult{}
+		case <-a.Context().Done():
+			killed <- terminate(c.Process, cfg, exited)
+		}
+	}()
+	err := c.Wait()
+	close(exited)
+	if k := <-killed; len(k.procs) > 0 {
+		if k.grace > 0 {
+			a.Logf("Killed processes still running after %v grace period: %s", k.grace, strings.Join(k.procs, ", "))
+		} else {
+			a.Logf("Killed processes: %s", strings.Join(k.procs, ", "))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
+
+// killResult holds the processes killed by terminate and the grace period
+// given to them, which is zero if they were killed immediately.
+type killResult struct {
+	procs []string
+	grace time.Duration
+}
+
+// terminate interrupts the process group of the program and kills it
+// if it is still running after the grace period.
+// The program is killed immediately if it cannot be interrupted.
+func terminate(p *os.Process, cfg *config, exited <-chan struct{}) killResult {
+	var grace time.Duration
+	if cfg.grace > 0 && signalGroup(p, cfg.interrupt) == nil {
+		grace = cfg.grace
+		timer := time.NewTimer(grace)
+		defer timer.Stop()
+		select {
+		case <-exited:
+		case <-timer.C:
+		}
+	}
+
+	// Child processes may outlive the program.
+	procs := groupProcesses(p.Pid)
+	select {
+	case <-exited:
+		if len(procs) == 0 {
+			killGroup(p) //nolint:errcheck // the processes may have already exited
+			return killResult{}
+		}
+	default:
+		if len(procs) == 0 {
+			procs = []string{strconv.Itoa(p.Pid)}
+		}
+	}
+	killGroup(p) //nolint:errcheck // the processes may have already exited
+	return killResult{procs: procs, grace: grace}
+}
+
+// ExitCode returns the exit code of the program run by [Run].
+// It returns 0 if err is nil and -1 if the program has not exited
+// normally, for example, because it could not be started
+// or it was killed by a signal.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
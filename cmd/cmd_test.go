@@ -0,0 +1,266 @@
+package cmd_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/cmd"
+)
+
+// TestMain runs the test binary as a helper program
+// if the GOYEK_CMD_HELPER environment variable is set.
+func TestMain(m *testing.M) {
+	if os.Getenv("GOYEK_CMD_HELPER") != "1" {
+		os.Exit(m.Run())
+	}
+	args := os.Args[1:]
+	switch args[0] {
+	case "echo":
+		fmt.Println(strings.Join(args[1:], " "))
+	case "stderr":
+		fmt.Fprintln(os.Stderr, strings.Join(args[1:], " "))
+	case "env":
+		fmt.Println(os.Getenv(args[1]))
+	case "pwd":
+		dir, _ := os.Getwd()
+		fmt.Println(dir)
+	case "cat":
+		b, _ := io.ReadAll(os.Stdin)
+		fmt.Print(string(b))
+	case "exit":
+		code, _ := strconv.Atoi(args[1])
+		os.Exit(code)
+	case "sleep":
+		fmt.Println("ready")
+		time.Sleep(time.Minute)
+	case "ignore":
+		signal.Ignore(syscall.SIGTERM)
+		fmt.Println("ready")
+		time.Sleep(time.Minute)
+	case "spawn":
+		signal.Ignore(syscall.SIGTERM)
+		child := exec.Command(os.Args[0], "ignore") //nolint:gosec // test helper
+		out, _ := child.StdoutPipe()
+		if err := child.Start(); err != nil {
+			os.Exit(1)
+		}
+		bufio.NewReader(out).ReadString('\n') //nolint:errcheck // waiting for the child to be ready
+		fmt.Println("ready")
+		time.Sleep(time.Minute)
+	}
+	os.Exit(0)
+}
+
+// helper returns the command line running the helper program.
+func helper(args string) string {
+	return "'" + os.Args[0] + "' " + args
+}
+
+func run(action func(a *goyek.A)) (goyek.Result, string) {
+	return runContext(context.Background(), action)
+}
+
+func runContext(ctx context.Context, action func(a *goyek.A)) (goyek.Result, string) {
+	out := &strings.Builder{}
+	res := goyek.NewRunner(action)(goyek.Input{
+		Context:  ctx,
+		TaskName: "task",
+		Output:   goyek.SyncWriter(out),
+		Logger:   goyek.FmtLogger{},
+	})
+	return res, out.String()
+}
+
+func TestExec(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+
+	res, out := run(func(a *goyek.A) {
+		cmd.Exec(a, helper(`echo "hello world"`))
+		cmd.Exec(a, helper("stderr oops"))
+	})
+
+	if res.Status != goyek.StatusPassed {
+		t.Errorf("got status %v, want PASS", res.Status)
+	}
+	for _, want := range []string{"Exec: ", "hello world\n", "oops\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("got: %q; should contain: %q", out, want)
+		}
+	}
+}
+
+func TestExecArgs(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+	args := []string{os.Args[0], "echo", "it's", `"a b"`, "$HOME", ""}
+
+	var ok bool
+	var got string
+	res, out := run(func(a *goyek.A) {
+		ok = cmd.ExecArgs(a, args)
+		got, _ = cmd.OutputArgs(a, args)
+	})
+
+	if !ok || res.Status != goyek.StatusPassed {
+		t.Errorf("got %v and status %v, want true and PASS", ok, res.Status)
+	}
+	if want := "it's \"a b\" $HOME \n"; got != want {
+		t.Errorf("got output %q, want %q", got, want)
+	}
+	line := strings.SplitN(strings.TrimPrefix(out, "Exec: "), "\n", 2)[0]
+	logged, err := cmd.Split(line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(logged, args) {
+		t.Errorf("got logged command line %q, should be split into %q", line, args)
+	}
+}
+
+func TestRunArgs_empty(t *testing.T) {
+	var err error
+	run(func(a *goyek.A) {
+		err = cmd.RunArgs(a, nil)
+	})
+
+	if err == nil {
+		t.Error("should return an error")
+	}
+}
+
+func TestExec_failure(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+
+	var ok bool
+	res, out := run(func(a *goyek.A) {
+		ok = cmd.Exec(a, helper("exit 3"))
+	})
+
+	if ok || res.Status != goyek.StatusFailed {
+		t.Errorf("got %v and status %v, want false and FAIL", ok, res.Status)
+	}
+	if want := "exit status 3\n"; !strings.HasSuffix(out, want) {
+		t.Errorf("got: %q; should end with: %q", out, want)
+	}
+}
+
+func TestOutput(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+	dir := t.TempDir()
+
+	var env, pwd, stdin string
+	res, _ := run(func(a *goyek.A) {
+		env, _ = cmd.Output(a, helper("env GOYEK_TEST_VALUE"), cmd.Env("GOYEK_TEST_VALUE", "value"))
+		pwd, _ = cmd.Output(a, helper("pwd"), cmd.Dir(dir))
+		stdin, _ = cmd.Output(a, helper("cat"), cmd.Stdin(strings.NewReader("input")))
+	})
+
+	if res.Status != goyek.StatusPassed {
+		t.Errorf("got status %v, want PASS", res.Status)
+	}
+	if env != "value\n" {
+		t.Errorf("got env %q", env)
+	}
+	wantDir, _ := filepath.EvalSymlinks(dir)
+	gotDir, _ := filepath.EvalSymlinks(strings.TrimSpace(pwd))
+	if gotDir != wantDir {
+		t.Errorf("got working directory %q, want %q", gotDir, wantDir)
+	}
+	if stdin != "input" {
+		t.Errorf("got stdin %q", stdin)
+	}
+}
+
+func TestRun_ExitCode(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+
+	var errs []error
+	res, _ := run(func(a *goyek.A) {
+		errs = append(errs,
+			cmd.Run(a, helper("exit 0")),
+			cmd.Run(a, helper("exit 5")),
+			cmd.Run(a, "goyek-non-existing-program"),
+			cmd.Run(a, ""),
+		)
+	})
+
+	if res.Status != goyek.StatusPassed {
+		t.Errorf("Run should not fail the task, got status %v", res.Status)
+	}
+	want := []int{0, 5, -1, -1}
+	for i, err := range errs {
+		if got := cmd.ExitCode(err); got != want[i] {
+			t.Errorf("ExitCode(%v) = %d, want %d", err, got, want[i])
+		}
+	}
+}
+
+// readyWriter calls fn when the helper program reports it is ready.
+type readyWriter func()
+
+func (fn readyWriter) Write(p []byte) (int, error) {
+	if strings.Contains(string(p), "ready") {
+		fn()
+	}
+	return len(p), nil
+}
+
+func TestExec_canceled(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	res, out := runContext(ctx, func(a *goyek.A) {
+		cmd.Exec(a, helper("sleep"), cmd.Stdout(readyWriter(cancel)))
+	})
+
+	if res.Status != goyek.StatusCanceled {
+		t.Errorf("got status %v, want CANCEL", res.Status)
+	}
+	if d := time.Since(start); d > cmd.DefaultGracePeriod {
+		t.Errorf("the program should be interrupted before the grace period, took %v", d)
+	}
+	if strings.Contains(out, "Killed") {
+		t.Errorf("got: %q; should not kill the interrupted program", out)
+	}
+}
+
+func TestExec_noGracePeriod(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	res, out := runContext(ctx, func(a *goyek.A) {
+		cmd.Exec(a, helper("ignore"), cmd.Stdout(readyWriter(cancel)), cmd.GracePeriod(0))
+	})
+
+	if res.Status != goyek.StatusCanceled {
+		t.Errorf("got status %v, want CANCEL", res.Status)
+	}
+	if want := "Killed processes: "; !strings.Contains(out, want) {
+		t.Errorf("got: %q; should contain: %q", out, want)
+	}
+	if strings.Contains(out, "grace period") {
+		t.Errorf("got: %q; should not mention the grace period", out)
+	}
+}
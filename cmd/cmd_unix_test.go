@@ -0,0 +1,70 @@
+//go:build aix || android || darwin || dragonfly || freebsd || hurd || illumos || ios || linux || netbsd || openbsd || solaris
+// +build aix android darwin dragonfly freebsd hurd illumos ios linux netbsd openbsd solaris
+
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/cmd"
+)
+
+func TestExec_gracePeriod(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	start := time.Now()
+	sb := &strings.Builder{}
+	res := goyek.NewRunner(func(a *goyek.A) {
+		cmd.Exec(a, helper("spawn"), cmd.Stdout(readyWriter(cancel)), cmd.GracePeriod(100*time.Millisecond))
+	})(goyek.Input{
+		Context:  ctx,
+		TaskName: "task",
+		Output:   goyek.SyncWriter(sb),
+		Logger:   &goyek.CodeLineLogger{},
+	})
+	out := sb.String()
+
+	if res.Status != goyek.StatusCanceled {
+		t.Errorf("got status %v, want CANCEL", res.Status)
+	}
+	if d := time.Since(start); d > 30*time.Second {
+		t.Errorf("the program should be killed after the grace period, took %v", d)
+	}
+	want := "cmd_unix_test.go:27: Killed processes still running after 100ms grace period: "
+	if !strings.Contains(out, want) {
+		t.Fatalf("got: %q; should contain: %q", out, want)
+	}
+	if runtime.GOOS == "linux" {
+		line := strings.SplitN(out[strings.Index(out, want)+len(want):], "\n", 2)[0]
+		if got := len(strings.Split(line, ", ")); got != 2 {
+			t.Errorf("got: %q; should report the program and its child", line)
+		}
+	}
+}
+
+func TestExec_interruptSignal(t *testing.T) {
+	os.Setenv("GOYEK_CMD_HELPER", "1")
+	defer os.Unsetenv("GOYEK_CMD_HELPER")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	res, out := runContext(ctx, func(a *goyek.A) {
+		cmd.Exec(a, helper("ignore"), cmd.Stdout(readyWriter(cancel)), cmd.InterruptSignal(os.Interrupt))
+	})
+
+	if res.Status != goyek.StatusCanceled {
+		t.Errorf("got status %v, want CANCEL", res.Status)
+	}
+	if want := "signal: interrupt"; !strings.Contains(out, want) {
+		t.Errorf("got: %q; should contain: %q", out, want)
+	}
+}
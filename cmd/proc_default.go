@@ -0,0 +1,30 @@
+//go:build !aix && !android && !darwin && !dragonfly && !freebsd && !hurd && !illumos && !ios && !linux && !netbsd && !openbsd && !solaris
+// +build !aix,!android,!darwin,!dragonfly,!freebsd,!hurd,!illumos,!ios,!linux,!netbsd,!openbsd,!solaris
+
+package cmd
+
+import (
+	"os"
+	"os/exec"
+)
+
+// defaultInterruptSignal is the signal sent to interrupt the program.
+var defaultInterruptSignal = os.Interrupt
+
+// setProcessGroup does nothing as process groups are not supported.
+func setProcessGroup(*exec.Cmd) {}
+
+// signalGroup sends the signal to the program.
+func signalGroup(p *os.Process, sig os.Signal) error {
+	return p.Signal(sig)
+}
+
+// killGroup kills the program.
+func killGroup(p *os.Process) error {
+	return p.Kill()
+}
+
+// groupProcesses returns nil as the processes cannot be listed.
+func groupProcesses(int) []string {
+	return nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"runtime"
+	"strings"
+)
+
+// Split splits the command line into the program and its arguments
+// using rules similar to a POSIX shell:
+//   - arguments are separated by unquoted whitespace,
+//   - characters inside single quotes are preserved literally,
+//   - inside double quotes, a backslash escapes only '"', '\', '$', and '`',
+//   - outside quotes, a backslash escapes the next character.
+//
+// On Windows, a backslash is not an escape character and is preserved
+// literally, so that paths like C:\Go\bin\go.exe can be used as they are.
+// Variables, globs, and other shell expansions are not supported.
+func Split(cmdLine string) ([]string, error) {
+	return split(cmdLine, runtime.GOOS != "windows")
+}
+
+// split splits the command line like [Split].
+// If escapes is false, a backslash is preserved literally.
+func split(cmdLine string, escapes bool) ([]string, error) {
+	var (
+		args    []string
+		arg     []rune
+		inArg   bool
+		quote   rune // '\'', '"', or 0 if not in quotes
+		escaped bool
+	)
+	for _, r := range cmdLine {
+		switch {
+		case escaped:
+			if quote == '"' && r != '"' && r != '\\' && r != '$' && r != '`' {
+				arg = append(arg, '\\')
+			}
+			arg = append(arg, r)
+			escaped = false
+		case r == '\\' && escapes && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				arg = append(arg, r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inArg {
+				args = append(args, string(arg))
+				arg = arg[:0]
+				inArg = false
+			}
+		default:
+			arg = append(arg, r)
+			inArg = true
+		}
+	}
+	if escaped {
+		return nil, errors.New("command line ends with an unfinished escape")
+	}
+	if quote != 0 {
+		return nil, errors.New("command line has an unterminated quote")
+	}
+	if inArg {
+		args = append(args, string(arg))
+	}
+	return args, nil
+}
+
+// join returns the command line which is split by [Split] into args.
+// It is used to log the programs run with the arguments.
+func join(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = quote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+	for _, r := range arg {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune("-_./:=@%+,", r)) {
+			// The single quote is put in double quotes, so that
+			// it does not depend on backslash escapes.
+			return "'" + strings.ReplaceAll(arg, "'", `'"'"'`) + "'"
+		}
+	}
+	return arg
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplit_windowsPaths(t *testing.T) {
+	cmdLine := `C:\Go\bin\go.exe build -o "C:\Program Files\app.exe" a\ b`
+	want := []string{`C:\Go\bin\go.exe`, "build", "-o", `C:\Program Files\app.exe`, `a\`, "b"}
+
+	got, err := split(cmdLine, false)
+
+	if err != nil {
+		t.Fatalf("split(%q) error: %v", cmdLine, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("split(%q) = %q, want %q", cmdLine, got, want)
+	}
+}
+
+func TestJoin(t *testing.T) {
+	args := []string{`C:\Go\bin\go.exe`, "it's", `"a b"`, "$HOME", ""}
+
+	for _, escapes := range []bool{true, false} {
+		got, err := split(join(args), escapes)
+		if err != nil {
+			t.Fatalf("split(%q) error: %v", join(args), err)
+		}
+		if !reflect.DeepEqual(got, args) {
+			t.Errorf("split(%q, %v) = %q, want %q", join(args), escapes, got, args)
+		}
+	}
+}
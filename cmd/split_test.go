@@ -0,0 +1,41 @@
+package cmd_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goyek/goyek/v3/cmd"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		cmdLine string
+		want    []string
+	}{
+		{cmdLine: "", want: nil},
+		{cmdLine: "  go   test\t./... ", want: []string{"go", "test", "./..."}},
+		{cmdLine: `go test -run 'Test A$'`, want: []string{"go", "test", "-run", "Test A$"}},
+		{cmdLine: `echo "a \"b\" \$c \d"`, want: []string{"echo", `a "b" $c \d`}},
+		{cmdLine: `echo a\ b '' "" 'x'"y"z`, want: []string{"echo", "a b", "", "", "xyz"}},
+		{cmdLine: `echo 'a\b'`, want: []string{"echo", `a\b`}},
+		{cmdLine: `'C:\Go\bin\go.exe' build`, want: []string{`C:\Go\bin\go.exe`, "build"}},
+	}
+	for _, tt := range tests {
+		got, err := cmd.Split(tt.cmdLine)
+		if err != nil {
+			t.Errorf("Split(%q) error: %v", tt.cmdLine, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Split(%q) = %q, want %q", tt.cmdLine, got, tt.want)
+		}
+	}
+}
+
+func TestSplit_error(t *testing.T) {
+	for _, cmdLine := range []string{`echo "a`, `echo 'a`, `echo a\`} {
+		if _, err := cmd.Split(cmdLine); err == nil {
+			t.Errorf("Split(%q) should return an error", cmdLine)
+		}
+	}
+}
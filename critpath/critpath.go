@@ -0,0 +1,394 @@
+// Package critpath provides the critical path analysis of a flow execution.
+//
+// The analysis uses the task timings recorded by [Recorder]
+// and the dependency graph of the defined tasks.
+// It reports the chain of dependencies which determined the duration
+// of the execution, the slack of each task, and an estimate
+// of how much faster the execution would be if a task was parallel.
+package critpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// Report is the result of the critical path analysis.
+type Report struct {
+	// Elapsed is the wall time between the start of the first
+	// and the end of the last recorded task.
+	Elapsed time.Duration
+
+	// Length is the duration of the critical path.
+	// It is the shortest possible duration of the execution
+	// if all tasks could run as soon as their dependencies finished.
+	Length time.Duration
+
+	// Path contains the names of the tasks on the critical path
+	// in the order of execution.
+	Path []string
+
+	// Tasks contains the analysis of each recorded task
+	// sorted by the earliest start and name.
+	Tasks []TaskReport
+}
+
+// TaskReport is the result of the critical path analysis for a single task.
+type TaskReport struct {
+	Name     string
+	Duration time.Duration
+	Parallel bool
+
+	// EarliestStart is the offset at which the task could start
+	// if it ran as soon as its dependencies finished.
+	EarliestStart time.Duration
+
+	// Slack is the duration by which the task could be delayed
+	// without delaying the whole execution.
+	Slack time.Duration
+
+	// Critical reports whether the task is on the critical path.
+	Critical bool
+
+	// ParallelGain is the estimated reduction of the execution duration
+	// if the task was parallel. It is always zero for parallel tasks.
+	ParallelGain time.Duration
+}
+
+// Analyze performs the critical path analysis of the recorded run.
+// Tasks which are not defined or have no recorded timing are ignored.
+//
+// The parallel gain is estimated by simulating the flow execution
+// using the recorded durations, so it is zero if [Run.Tasks] is empty.
+func Analyze(tasks []*goyek.DefinedTask, run Run) *Report {
+	g := newGraph(tasks, run.Timings)
+	order := g.topoSort()
+
+	earliestFinish := map[string]time.Duration{}
+	earliestStart := map[string]time.Duration{}
+	var length time.Duration
+	for _, name := range order {
+		var start time.Duration
+		for _, dep := range g.deps[name] {
+			if earliestFinish[dep] > start {
+				start = earliestFinish[dep]
+			}
+		}
+		earliestStart[name] = start
+		earliestFinish[name] = start + g.durations[name]
+		if earliestFinish[name] > length {
+			length = earliestFinish[name]
+		}
+	}
+
+	latestFinish := map[string]time.Duration{}
+	for i := len(order) - 1; i >= 0; i-- {
+		name := order[i]
+		finish := length
+		for _, dependent := range g.dependents[name] {
+			if start := latestFinish[dependent] - g.durations[dependent]; start < finish {
+				finish = start
+			}
+		}
+		latestFinish[name] = finish
+	}
+
+	report := &Report{
+		Elapsed: run.elapsed(),
+		Length:  length,
+		Path:    g.criticalPath(order, earliestStart, earliestFinish, length),
+	}
+
+	critical := map[string]bool{}
+	for _, name := range report.Path {
+		critical[name] = true
+	}
+	baseline := g.simulate(run, "")
+	for _, name := range order {
+		task := TaskReport{
+			Name:          name,
+			Duration:      g.durations[name],
+			Parallel:      g.parallel[name],
+			EarliestStart: earliestStart[name],
+			Slack:         latestFinish[name] - earliestFinish[name],
+			Critical:      critical[name],
+		}
+		if !task.Parallel {
+			if gain := baseline - g.simulate(run, name); gain > 0 {
+				task.ParallelGain = gain
+			}
+		}
+		report.Tasks = append(report.Tasks, task)
+	}
+	sort.SliceStable(report.Tasks, func(i, j int) bool {
+		if report.Tasks[i].EarliestStart != report.Tasks[j].EarliestStart {
+			return report.Tasks[i].EarliestStart < report.Tasks[j].EarliestStart
+		}
+		return report.Tasks[i].Name < report.Tasks[j].Name
+	})
+	return report
+}
+
+// WriteText writes the report in a human-readable format.
+func (r *Report) WriteText(w io.Writer) error {
+	var (
+		minwidth      = 5
+		tabwidth      = 0
+		padding       = 2
+		padchar  byte = ' '
+	)
+	tw := tabwriter.NewWriter(w, minwidth, tabwidth, padding, padchar, 0)
+	fmt.Fprintf(tw, "Critical path (%.3fs): %s\n", r.Length.Seconds(), strings.Join(r.Path, " -> "))
+	fmt.Fprintf(tw, "Elapsed: %.3fs\n", r.Elapsed.Seconds())
+	fmt.Fprintln(tw, "Tasks:")
+	fmt.Fprintln(tw, "  NAME\tDURATION\tSLACK\tCRITICAL\tPARALLEL GAIN")
+	for _, task := range r.Tasks {
+		critical := ""
+		if task.Critical {
+			critical = "yes"
+		}
+		gain := "-"
+		if !task.Parallel {
+			gain = fmt.Sprintf("%.3fs", task.ParallelGain.Seconds())
+		}
+		fmt.Fprintf(tw, "  %s\t%.3fs\t%.3fs\t%s\t%s\n",
+			task.Name, task.Duration.Seconds(), task.Slack.Seconds(), critical, gain)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the report in the JSON format.
+// Durations are expressed in seconds.
+func (r *Report) WriteJSON(w io.Writer) error {
+	type jsonTask struct {
+		Name          string  `json:"name"`
+		Duration      float64 `json:"duration"`
+		Parallel      bool    `json:"parallel"`
+		EarliestStart float64 `json:"earliestStart"`
+		Slack         float64 `json:"slack"`
+		Critical      bool    `json:"critical"`
+		ParallelGain  float64 `json:"parallelGain"`
+	}
+	type jsonReport struct {
+		Elapsed float64    `json:"elapsed"`
+		Length  float64    `json:"length"`
+		Path    []string   `json:"path"`
+		Tasks   []jsonTask `json:"tasks"`
+	}
+
+	report := jsonReport{
+		Elapsed: r.Elapsed.Seconds(),
+		Length:  r.Length.Seconds(),
+		Path:    r.Path,
+		Tasks:   []jsonTask{},
+	}
+	if report.Path == nil {
+		report.Path = []string{}
+	}
+	for _, task := range r.Tasks {
+		report.Tasks = append(report.Tasks, jsonTask{
+			Name:          task.Name,
+			Duration:      task.Duration.Seconds(),
+			Parallel:      task.Parallel,
+			EarliestStart: task.EarliestStart.Seconds(),
+			Slack:         task.Slack.Seconds(),
+			Critical:      task.Critical,
+			ParallelGain:  task.ParallelGain.Seconds(),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+func (r Run) elapsed() time.Duration {
+	var first, last time.Time
+	for _, timing := range r.Timings {
+		if first.IsZero() || timing.Start.Before(first) {
+			first = timing.Start
+		}
+		if timing.End.After(last) {
+			last = timing.End
+		}
+	}
+	return last.Sub(first)
+}
+
+// graph is the dependency graph of the defined tasks.
+type graph struct {
+	names      []string // sorted names of the defined tasks
+	deps       map[string][]string
+	dependents map[string][]string
+	parallel   map[string]bool
+	durations  map[string]time.Duration
+	recorded   map[string]bool
+}
+
+func newGraph(tasks []*goyek.DefinedTask, timings map[string]Timing) *graph {
+	g := &graph{
+		deps:       map[string][]string{},
+		dependents: map[string][]string{},
+		parallel:   map[string]bool{},
+		durations:  map[string]time.Duration{},
+		recorded:   map[string]bool{},
+	}
+	for _, task := range tasks {
+		name := task.Name()
+		g.names = append(g.names, name)
+		g.parallel[name] = task.Parallel()
+		if timing, ok := timings[name]; ok {
+			g.recorded[name] = true
+			g.durations[name] = timing.Duration()
+		}
+		for _, dep := range task.Deps() {
+			g.deps[name] = append(g.deps[name], dep.Name())
+		}
+	}
+	sort.Strings(g.names)
+	for _, name := range g.names {
+		if !g.recorded[name] {
+			continue
+		}
+		for _, dep := range g.deps[name] {
+			if g.recorded[dep] {
+				g.dependents[dep] = append(g.dependents[dep], name)
+			}
+		}
+	}
+	return g
+}
+
+// topoSort returns the recorded tasks in a topological order.
+func (g *graph) topoSort() []string {
+	var order []string
+	visited := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dep := range g.deps[name] {
+			visit(dep)
+		}
+		if g.recorded[name] {
+			order = append(order, name)
+		}
+	}
+	for _, name := range g.names {
+		visit(name)
+	}
+	return order
+}
+
+func (g *graph) criticalPath(order []string, earliestStart, earliestFinish map[string]time.Duration, length time.Duration) []string {
+	// Find the last task finishing at the end of the critical path.
+	last := ""
+	for _, name := range order {
+		if earliestFinish[name] == length && (last == "" || name < last) {
+			last = name
+		}
+	}
+	if last == "" {
+		return nil
+	}
+
+	path := []string{last}
+	for current := last; ; {
+		next := ""
+		for _, dep := range g.deps[current] {
+			if !g.recorded[dep] || earliestFinish[dep] != earliestStart[current] {
+				continue
+			}
+			if next == "" || dep < next {
+				next = dep
+			}
+		}
+		if next == "" {
+			break
+		}
+		path = append(path, next)
+		current = next
+	}
+
+	// Reverse to have the order of execution.
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// simulate returns the duration of the flow execution
+// estimated using the recorded durations.
+// It mirrors the scheduling done by the flow executor,
+// treating the task named parallel as a parallel task.
+//
+//nolint:gocyclo // Mirrors the graph traversal logic of the executor.
+func (g *graph) simulate(run Run, parallel string) time.Duration {
+	isParallel := func(name string) bool {
+		return name == parallel || g.parallel[name]
+	}
+	visited := map[string]bool{}
+	for _, name := range run.SkipTasks {
+		visited[name] = true
+	}
+	canRun := func(name string) bool {
+		if visited[name] || !isParallel(name) {
+			return false
+		}
+		if run.NoDeps {
+			return true
+		}
+		for _, dep := range g.deps[name] {
+			if !visited[dep] {
+				return false
+			}
+		}
+		return true
+	}
+
+	var total time.Duration
+	tasks := append([]string(nil), run.Tasks...)
+	for len(tasks) > 0 {
+		name := tasks[0]
+		tasks = tasks[1:]
+		if visited[name] {
+			continue
+		}
+		if !run.NoDeps {
+			var deps []string
+			for _, dep := range g.deps[name] {
+				if !visited[dep] {
+					deps = append(deps, dep)
+				}
+			}
+			if len(deps) > 0 {
+				deps = append(deps, name)
+				tasks = append(deps, tasks...)
+				continue
+			}
+		}
+
+		visited[name] = true
+		duration := g.durations[name]
+		if isParallel(name) {
+			for _, next := range tasks {
+				if !canRun(next) {
+					continue
+				}
+				visited[next] = true
+				if g.durations[next] > duration {
+					duration = g.durations[next]
+				}
+			}
+		}
+		total += duration
+	}
+	return total
+}
@@ -0,0 +1,176 @@
+package critpath_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/critpath"
+)
+
+func TestAnalyze(t *testing.T) {
+	flow := &goyek.Flow{}
+	a := flow.Define(goyek.Task{Name: "a"})
+	b := flow.Define(goyek.Task{Name: "b", Parallel: true})
+	c := flow.Define(goyek.Task{Name: "c", Deps: goyek.Deps{a}})
+	d := flow.Define(goyek.Task{Name: "d", Parallel: true})
+	flow.Define(goyek.Task{Name: "all", Deps: goyek.Deps{a, b, c, d}})
+
+	start := time.Now()
+	timing := func(from, to int) critpath.Timing {
+		return critpath.Timing{
+			Start: start.Add(time.Duration(from) * time.Second),
+			End:   start.Add(time.Duration(to) * time.Second),
+		}
+	}
+	run := critpath.Run{
+		Tasks: []string{"all"},
+		Timings: map[string]critpath.Timing{
+			"a":   timing(0, 1),
+			"b":   timing(1, 4),
+			"d":   timing(1, 3),
+			"c":   timing(4, 5),
+			"all": timing(5, 5),
+		},
+	}
+
+	got := critpath.Analyze(flow.Tasks(), run)
+
+	if want := []string{"b", "all"}; !reflect.DeepEqual(got.Path, want) {
+		t.Errorf("got path %v, want %v", got.Path, want)
+	}
+	if want := 3 * time.Second; got.Length != want {
+		t.Errorf("got length %v, want %v", got.Length, want)
+	}
+	if want := 5 * time.Second; got.Elapsed != want {
+		t.Errorf("got elapsed %v, want %v", got.Elapsed, want)
+	}
+	tasks := map[string]critpath.TaskReport{}
+	for _, task := range got.Tasks {
+		tasks[task.Name] = task
+	}
+	wantTasks := map[string]critpath.TaskReport{
+		"a":   {Name: "a", Duration: time.Second, Slack: time.Second, ParallelGain: time.Second},
+		"b":   {Name: "b", Duration: 3 * time.Second, Parallel: true, Critical: true},
+		"c":   {Name: "c", Duration: time.Second, EarliestStart: time.Second, Slack: time.Second, ParallelGain: time.Second},
+		"d":   {Name: "d", Duration: 2 * time.Second, Parallel: true, Slack: time.Second},
+		"all": {Name: "all", EarliestStart: 3 * time.Second, Critical: true},
+	}
+	if !reflect.DeepEqual(tasks, wantTasks) {
+		t.Errorf("got tasks:\n%+v\nwant:\n%+v", tasks, wantTasks)
+	}
+}
+
+func TestAnalyze_empty(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.Define(goyek.Task{Name: "task"})
+
+	got := critpath.Analyze(flow.Tasks(), critpath.Run{})
+
+	if got.Path != nil || got.Tasks != nil || got.Length != 0 {
+		t.Errorf("got %+v, want empty report", got)
+	}
+}
+
+func TestReport_WriteText(t *testing.T) {
+	report := &critpath.Report{
+		Elapsed: 2 * time.Second,
+		Length:  time.Second,
+		Path:    []string{"build", "test"},
+		Tasks: []critpath.TaskReport{
+			{Name: "build", Duration: time.Second, Critical: true, ParallelGain: time.Second},
+			{Name: "lint", Duration: time.Second, Parallel: true},
+		},
+	}
+	sb := &strings.Builder{}
+
+	if err := report.WriteText(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	got := sb.String()
+	for _, want := range []string{
+		"Critical path (1.000s): build -> test",
+		"Elapsed: 2.000s",
+		"build  1.000s    0.000s  yes       1.000s",
+		"lint   1.000s    0.000s            -",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got:\n%s\nshould contain: %q", got, want)
+		}
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	report := &critpath.Report{
+		Length: 1500 * time.Millisecond,
+		Path:   []string{"build"},
+		Tasks:  []critpath.TaskReport{{Name: "build", Duration: 1500 * time.Millisecond, Critical: true}},
+	}
+	sb := &strings.Builder{}
+
+	if err := report.WriteJSON(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Length float64 `json:"length"`
+		Path   []string
+		Tasks  []struct {
+			Name     string  `json:"name"`
+			Duration float64 `json:"duration"`
+			Critical bool    `json:"critical"`
+		}
+	}
+	if err := json.Unmarshal([]byte(sb.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Length != 1.5 || len(got.Tasks) != 1 || got.Tasks[0].Name != "build" ||
+		got.Tasks[0].Duration != 1.5 || !got.Tasks[0].Critical {
+		t.Errorf("got %s", sb.String())
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	recorder := &critpath.Recorder{}
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.UseExecutor(recorder.Executor)
+	flow.Use(recorder.Runner)
+	dep := flow.Define(goyek.Task{
+		Name:   "dep",
+		Action: func(*goyek.A) { time.Sleep(10 * time.Millisecond) },
+	})
+	flow.Define(goyek.Task{Name: "task", Deps: goyek.Deps{dep}})
+
+	if err := flow.Execute(context.Background(), []string{"task"}, goyek.NoDeps()); err != nil {
+		t.Fatal(err)
+	}
+	if err := flow.Execute(context.Background(), []string{"task"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := recorder.Run()
+	if !reflect.DeepEqual(got.Tasks, []string{"task"}) || got.NoDeps {
+		t.Errorf("got %+v, want the input of the last execution", got)
+	}
+	if len(got.Timings) != 2 {
+		t.Fatalf("got %d timings, want 2", len(got.Timings))
+	}
+	if d := got.Timings["dep"].Duration(); d < 10*time.Millisecond {
+		t.Errorf("got dep duration %v, want at least 10ms", d)
+	}
+	if got.Timings["task"].Start.Before(got.Timings["dep"].End) {
+		t.Error("task should start after its dependency ends")
+	}
+
+	report := critpath.Analyze(flow.Tasks(), got)
+	if want := []string{"dep", "task"}; !reflect.DeepEqual(report.Path, want) {
+		t.Errorf("got path %v, want %v", report.Path, want)
+	}
+}
@@ -0,0 +1,85 @@
+package critpath
+
+import (
+	"sync"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// Timing is the time span of a task run.
+type Timing struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration returns the duration of the task run.
+func (t Timing) Duration() time.Duration {
+	return t.End.Sub(t.Start)
+}
+
+// Run contains data recorded during a flow execution.
+type Run struct {
+	Tasks     []string
+	SkipTasks []string
+	NoDeps    bool
+	Timings   map[string]Timing
+}
+
+// Recorder records the timings of task runs.
+//
+// Use [Recorder.Executor] with [goyek.Flow.UseExecutor]
+// and [Recorder.Runner] with [goyek.Flow.Use].
+// The recorded data is reset at the start of every flow execution.
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	mu  sync.Mutex
+	run Run
+}
+
+// Executor is a flow executor middleware which records the execution input.
+func (r *Recorder) Executor(next goyek.Executor) goyek.Executor {
+	return func(in goyek.ExecuteInput) error {
+		r.mu.Lock()
+		r.run = Run{
+			Tasks:     append([]string(nil), in.Tasks...),
+			SkipTasks: append([]string(nil), in.SkipTasks...),
+			NoDeps:    in.NoDeps,
+			Timings:   map[string]Timing{},
+		}
+		r.mu.Unlock()
+		return next(in)
+	}
+}
+
+// Runner is a task runner middleware which records the timing of the task run.
+func (r *Recorder) Runner(next goyek.Runner) goyek.Runner {
+	return func(in goyek.Input) goyek.Result {
+		start := time.Now()
+		defer func() {
+			end := time.Now()
+			r.mu.Lock()
+			if r.run.Timings == nil {
+				r.run.Timings = map[string]Timing{}
+			}
+			r.run.Timings[in.TaskName] = Timing{Start: start, End: end}
+			r.mu.Unlock()
+		}()
+		return next(in)
+	}
+}
+
+// Run returns a copy of the data recorded during the last flow execution.
+func (r *Recorder) Run() Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run := r.run
+	run.Tasks = append([]string(nil), r.run.Tasks...)
+	run.SkipTasks = append([]string(nil), r.run.SkipTasks...)
+	run.Timings = make(map[string]Timing, len(r.run.Timings))
+	for name, timing := range r.run.Timings {
+		run.Timings[name] = timing
+	}
+	return run
+}
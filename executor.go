@@ -27,8 +27,12 @@ type (
 		// non-nil Output supplied by another caller must be safe for concurrent
 		// use; use [SyncWriter] to adapt a writer that does not provide its own
 		// synchronization.
-		Output io.Writer
-		Logger Logger
+		Output   io.Writer
+		Logger   Logger
+		LogLevel LogLevel
+
+		values  *valueStore // set by the executor
+		secrets []string    // set by Flow.Execute
 	}
 
 	// ExecutorMiddleware represents a flow execution interceptor.
@@ -45,6 +49,7 @@ type (
 	executor struct {
 		defined     map[string]*taskSnapshot
 		middlewares []Middleware
+		resources   *resourcePool
 	}
 )
 
@@ -59,6 +64,7 @@ func (r *executor) Execute(in ExecuteInput) error {
 	if err := r.validate(in); err != nil {
 		return err
 	}
+	in.values = newValueStore(r.defined)
 
 	visited := map[string]bool{}
 	for _, skipTask := range in.SkipTasks {
@@ -67,7 +73,6 @@ func (r *executor) Execute(in ExecuteInput) error {
 
 	ctx := in.Context
 	tasks := in.Tasks
-	out := in.Output
 	for len(tasks) > 0 {
 		name := tasks[0]
 		tasks = tasks[1:]
@@ -99,7 +104,7 @@ func (r *executor) Execute(in ExecuteInput) error {
 
 		if !task.parallel {
 			// Run task sychronously.
-			if err := r.runTask(ctx, task, out, in.Logger); err != nil {
+			if err := r.runTask(in, task); err != nil {
 				return err
 			}
 			continue
@@ -120,7 +125,7 @@ func (r *executor) Execute(in ExecuteInput) error {
 		}
 
 		// Run parallel tasks.
-		if err := r.runParallelTasks(ctx, tasksToRun, out, in.Logger); err != nil {
+		if err := r.runParallelTasks(in, tasksToRun); err != nil {
 			return err
 		}
 	}
@@ -177,13 +182,22 @@ func (r *executor) canRunTask(task *taskSnapshot, visited map[string]bool, noDep
 	return true
 }
 
-func (r *executor) runParallelTasks(ctx context.Context, tasks []*taskSnapshot, output io.Writer, logger Logger) error {
+func (r *executor) runParallelTasks(in ExecuteInput, tasks []*taskSnapshot) error {
 	var err error
 	errCh := make(chan error, len(tasks))
 	for _, parallelTask := range tasks {
 		parallelTask := parallelTask
 		go func() {
-			errCh <- r.runTask(ctx, parallelTask, output, logger)
+			// Wait for the tasks using the same resources.
+			r.resources.acquire(parallelTask.resources)
+			defer r.resources.release(parallelTask.resources)
+			if len(parallelTask.resources) > 0 {
+				if err := in.Context.Err(); err != nil {
+					errCh <- err
+					return
+				}
+			}
+			errCh <- r.runTask(in, parallelTask)
 		}()
 	}
 	for range tasks {
@@ -194,9 +208,20 @@ func (r *executor) runParallelTasks(ctx context.Context, tasks []*taskSnapshot,
 	return err
 }
 
-func (r *executor) runTask(ctx context.Context, task *taskSnapshot, output io.Writer, logger Logger) error {
+func (r *executor) runTask(in ExecuteInput, task *taskSnapshot) error {
 	// prepare runner
-	runner := NewRunner(task.action)
+	action := task.action
+	if action != nil && task.condition != nil {
+		if ok, reason := task.condition(in.Context); !ok {
+			action = func(a *A) {
+				if reason == "" {
+					a.SkipNow()
+				}
+				a.Skip(reason)
+			}
+		}
+	}
+	runner := NewRunner(action)
 
 	// apply defined middlewares
 	for _, middleware := range r.middlewares {
@@ -204,16 +229,28 @@ func (r *executor) runTask(ctx context.Context, task *taskSnapshot, output io.Wr
 	}
 
 	// run action
-	in := Input{
-		Context:  ctx,
+	result := runner(Input{
+		Context:  in.Context,
 		TaskName: task.name,
 		Parallel: task.parallel,
-		Output:   output,
-		Logger:   logger,
-	}
-	result := runner(in)
-	if result.Status == StatusFailed {
-		return &FailError{Task: task.name}
+		Output:   in.Output,
+		Logger:   in.Logger,
+		LogLevel: in.LogLevel,
+
+		exec: &execState{
+			values:      in.values,
+			secrets:     in.secrets,
+			definedTask: task.name,
+			middlewares: r.middlewares,
+		},
+	})
+	switch result.Status {
+	case StatusFailed:
+		return &FailError{Task: task.name, ExitCode: result.ExitCode}
+	case StatusCanceled:
+		return &FailError{Task: task.name, Err: context.Canceled, ExitCode: result.ExitCode}
+	case StatusTimedOut:
+		return &FailError{Task: task.name, Err: context.DeadlineExceeded, ExitCode: result.ExitCode}
 	}
 	return nil
 }
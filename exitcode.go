@@ -0,0 +1,104 @@
+package goyek
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Outcome is the outcome of the flow execution run by [Flow.Main].
+// See [ExitCode].
+type Outcome struct {
+	// Err is the error returned by [Flow.Execute].
+	Err error
+	// Statuses contains the statuses of the tasks and subtasks
+	// which were run, by task name.
+	Statuses map[string]Status
+	// ExitCode is the exit code used by [Flow.Main] by default.
+	ExitCode int
+}
+
+// ExitCode is an option for [Flow.Main] to set the function which returns
+// the exit code of the program for the outcome of the flow execution.
+// It is not called if the program exits because of the second
+// termination signal or the expiration of the grace period.
+func ExitCode(fn func(Outcome) int) Option {
+	if fn == nil {
+		panic("nil exit code function")
+	}
+	return optionFunc(func(c *config) {
+		c.exitCode = fn
+	})
+}
+
+// SetExitCode sets the exit code used by [Flow.Main] if the task fails,
+// unless the execution was interrupted. A failed subtask passes its
+// exit code to the parent task if the parent has not set one.
+// It panics if the code is not positive.
+func (a *A) SetExitCode(code int) {
+	if code <= 0 {
+		panic("exit code must be positive")
+	}
+	a.mu.Lock()
+	*a.exitCode = code
+	a.mu.Unlock()
+}
+
+// ExitCode returns the exit code set using [A.SetExitCode]
+// or 0 if it was not set.
+func (a *A) ExitCode() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return *a.exitCode
+}
+
+// defaultExitCode returns the exit code for the error returned by Execute.
+func defaultExitCode(err error) int {
+	if errors.Is(err, context.Canceled) {
+		return exitCodeInterrupted
+	}
+	var ferr *FailError
+	if errors.As(err, &ferr) {
+		if ferr.ExitCode != 0 {
+			return ferr.ExitCode
+		}
+		return exitCodeFail
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return exitCodeFail
+	}
+	if err != nil {
+		return exitCodeInvalid
+	}
+	return exitCodePass
+}
+
+// taskStatuses records the statuses of the run tasks.
+type taskStatuses struct {
+	mu       sync.Mutex
+	statuses map[string]Status
+}
+
+func newTaskStatuses() *taskStatuses {
+	return &taskStatuses{statuses: map[string]Status{}}
+}
+
+func (s *taskStatuses) middleware(next Runner) Runner {
+	return func(in Input) Result {
+		res := next(in)
+		s.mu.Lock()
+		s.statuses[in.TaskName] = res.Status
+		s.mu.Unlock()
+		return res
+	}
+}
+
+func (s *taskStatuses) snapshot() map[string]Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	statuses := make(map[string]Status, len(s.statuses))
+	for name, status := range s.statuses {
+		statuses[name] = status
+	}
+	return statuses
+}
@@ -2,7 +2,6 @@ package goyek
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +9,7 @@ import (
 	"sort"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/goyek/goyek/v3/internal"
 )
@@ -20,9 +20,16 @@ import (
 //
 // A Flow is not safe for concurrent use.
 type Flow struct {
-	output io.Writer
-	usage  func()
-	logger Logger
+	output   io.Writer
+	usage    func()
+	logger   Logger
+	logLevel LogLevel
+
+	secrets    []string // values to mask in the output
+	secretEnvs []string // environment variables to mask in the output
+	stateFile  string   // file persisting the task statuses
+
+	resourceLimits map[string]int // resource name -> number of tasks using it at once
 
 	tasks               map[string]*taskSnapshot // snapshot of defined tasks
 	defaultTask         *taskSnapshot            // task to run when none is explicitly provided
@@ -68,11 +75,13 @@ func (f *Flow) Define(task Task) *DefinedTask {
 	}
 
 	taskCopy := &taskSnapshot{
-		name:     task.Name,
-		usage:    task.Usage,
-		deps:     f.snapshotDeps(task.Deps),
-		action:   task.Action,
-		parallel: task.Parallel,
+		name:      task.Name,
+		usage:     task.Usage,
+		deps:      f.snapshotDeps(task.Deps),
+		action:    task.Action,
+		parallel:  task.Parallel,
+		condition: task.Condition,
+		resources: copyResources(task.Resources),
 	}
 	f.tasks[task.Name] = taskCopy
 	return &DefinedTask{taskSnapshot: taskCopy, flow: f}
@@ -207,6 +216,12 @@ func (f *Flow) Logger() Logger {
 //	Fatalf(w io.Writer, format string, args ...interface{})
 //	Skip(w io.Writer, args ...interface{})
 //	Skipf(w io.Writer, format string, args ...interface{})
+//	Debug(w io.Writer, args ...interface{})
+//	Debugf(w io.Writer, format string, args ...interface{})
+//	Info(w io.Writer, args ...interface{})
+//	Infof(w io.Writer, format string, args ...interface{})
+//	Warn(w io.Writer, args ...interface{})
+//	Warnf(w io.Writer, format string, args ...interface{})
 //	Helper()
 func SetLogger(logger Logger) {
 	DefaultFlow.SetLogger(logger)
@@ -222,11 +237,47 @@ func SetLogger(logger Logger) {
 //	Fatalf(w io.Writer, format string, args ...interface{})
 //	Skip(w io.Writer, args ...interface{})
 //	Skipf(w io.Writer, format string, args ...interface{})
+//	Debug(w io.Writer, args ...interface{})
+//	Debugf(w io.Writer, format string, args ...interface{})
+//	Info(w io.Writer, args ...interface{})
+//	Infof(w io.Writer, format string, args ...interface{})
+//	Warn(w io.Writer, args ...interface{})
+//	Warnf(w io.Writer, format string, args ...interface{})
 //	Helper()
 func (f *Flow) SetLogger(logger Logger) {
 	f.logger = logger
 }
 
+// GetLogLevel returns the minimum level of the messages logged
+// by [A.Debug], [A.Info], [A.Warn], and their variants.
+// [LevelInfo] is returned if the level was not set.
+func GetLogLevel() LogLevel {
+	return DefaultFlow.LogLevel()
+}
+
+// LogLevel returns the minimum level of the messages logged
+// by [A.Debug], [A.Info], [A.Warn], and their variants.
+// [LevelInfo] is returned if the level was not set.
+func (f *Flow) LogLevel() LogLevel {
+	return f.logLevel
+}
+
+// SetLogLevel sets the minimum level of the messages logged
+// by [A.Debug], [A.Info], [A.Warn], and their variants.
+// For example, use [LevelDebug] for a verbose run
+// and [LevelWarn] for a quiet one.
+func SetLogLevel(level LogLevel) {
+	DefaultFlow.SetLogLevel(level)
+}
+
+// SetLogLevel sets the minimum level of the messages logged
+// by [A.Debug], [A.Info], [A.Warn], and their variants.
+// For example, use [LevelDebug] for a verbose run
+// and [LevelWarn] for a quiet one.
+func (f *Flow) SetLogLevel(level LogLevel) {
+	f.logLevel = level
+}
+
 // Usage returns a function that prints a usage message documenting the flow.
 // It is called when an error occurs while parsing the flow.
 // [Print] is returned if a function was not set or was set to nil.
@@ -332,8 +383,27 @@ func (fn optionFunc) apply(cfg *config) {
 }
 
 type config struct {
-	noDeps    bool
-	skipTasks []string
+	noDeps      bool
+	skipTasks   []string
+	rerunFailed bool
+	resume      bool
+
+	// used only by Main
+	interruptSignals []os.Signal
+	interruptGrace   time.Duration
+	stopMessage      string
+	exitMessage      string
+	timeoutMessage   string
+	onInterrupt      []func(os.Signal)
+	dumpOnQuit       bool
+	running          *runningTasks
+	exitCode         func(Outcome) int
+	statuses         *taskStatuses
+
+	// used only by Watch
+	watchInterval time.Duration
+	watchDebounce *time.Duration
+	watchExclude  []string
 }
 
 // NoDeps is an option to skip processing of all dependencies.
@@ -353,12 +423,26 @@ func Skip(tasks ...string) Option {
 // FailError pointer is returned by [Flow.Execute] when a task failed.
 type FailError struct {
 	Task string
+	// Err is [context.Canceled] if the task status is [StatusCanceled],
+	// [context.DeadlineExceeded] if the task status is [StatusTimedOut],
+	// and nil otherwise.
+	Err error
+	// ExitCode is the exit code set by the task using [A.SetExitCode].
+	ExitCode int
 }
 
 func (err *FailError) Error() string {
+	if err.Err != nil {
+		return "task failed: " + err.Task + ": " + err.Err.Error()
+	}
 	return "task failed: " + err.Task
 }
 
+// Unwrap returns the cause of the task failure.
+func (err *FailError) Unwrap() error {
+	return err.Err
+}
+
 // Execute runs provided tasks and all their dependencies.
 // Each task is executed at most once.
 // Returns nil if no task has failed,
@@ -380,7 +464,11 @@ func Execute(ctx context.Context, tasks []string, opts ...Option) error {
 //
 // Execute adapts the configured output for concurrent use before invoking
 // executor middleware. The same writer is passed through the execution unless
-// middleware replaces it.
+// middleware replaces it. The secrets registered using [Flow.MaskSecrets]
+// and [Flow.MaskEnv] are masked in the output of the tasks.
+//
+// If the state file is set using [Flow.SetStateFile],
+// the statuses of the run tasks are saved in it.
 func (f *Flow) Execute(ctx context.Context, tasks []string, opts ...Option) error {
 	var middlewares []Middleware
 	middlewares = append(middlewares, f.middlewares...)
@@ -393,11 +481,25 @@ func (f *Flow) Execute(ctx context.Context, tasks []string, opts ...Option) erro
 	for _, opt := range opts {
 		opt.apply(cfg)
 	}
+	if cfg.running != nil {
+		middlewares = append(middlewares, cfg.running.middleware)
+	}
+	if cfg.statuses != nil {
+		middlewares = append(middlewares, cfg.statuses.middleware)
+	}
+	var state *stateRecorder
+	if f.stateFile != "" {
+		state = newStateRecorder(f)
+		middlewares = append(middlewares, state.middleware)
+	} else if cfg.rerunFailed || cfg.resume {
+		return errNoStateFile
+	}
 
 	// prepare runner
 	r := &executor{
 		defined:     f.tasks,
 		middlewares: middlewares,
+		resources:   newResourcePool(f.resourceLimits),
 	}
 	runner := r.Execute
 
@@ -406,21 +508,54 @@ func (f *Flow) Execute(ctx context.Context, tasks []string, opts ...Option) erro
 		runner = middleware(runner)
 	}
 
+	out := SyncWriter(f.Output())
+
+	skipTasks := cfg.skipTasks
+	if state != nil {
+		passed, msg, err := state.start(tasks, cfg)
+		if err != nil {
+			return err
+		}
+		if msg != "" {
+			fmt.Fprintln(out, msg)
+		}
+		skipTasks = append(append([]string(nil), skipTasks...), passed...)
+	}
+	if cfg.rerunFailed {
+		var err error
+		if tasks, err = f.failedTasks(tasks, cfg); err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			fmt.Fprintln(out, "no failed tasks to rerun")
+			return nil
+		}
+	}
+
 	in := ExecuteInput{
 		Context:   ctx,
 		Tasks:     tasks,
-		SkipTasks: cfg.skipTasks,
+		SkipTasks: skipTasks,
 		NoDeps:    cfg.noDeps,
-		Output:    SyncWriter(f.Output()),
+		Output:    out,
 		Logger:    f.Logger(),
+		LogLevel:  f.LogLevel(),
+		secrets:   f.secretValues(),
 	}
-	return runner(in)
+	err := runner(in)
+	if state != nil {
+		if saveErr := state.finish(err); saveErr != nil {
+			fmt.Fprintln(out, "cannot save state file:", saveErr)
+		}
+	}
+	return err
 }
 
 const (
-	exitCodePass    = 0
-	exitCodeFail    = 1
-	exitCodeInvalid = 2
+	exitCodePass        = 0
+	exitCodeFail        = 1
+	exitCodeInvalid     = 2
+	exitCodeInterrupted = 3
 )
 
 // Main runs provided tasks and all their dependencies.
@@ -431,8 +566,17 @@ const (
 // It exits the current program after the run is finished
 // or a termination signal interrupted the execution.
 //   - 0 exit code means that none of the tasks failed.
-//   - 1 exit code means that a task has failed or the execution was interrupted.
+//   - 1 exit code means that a task has failed.
 //   - 2 exit code means that the input was invalid.
+//   - 3 exit code means that the execution was interrupted.
+//
+// A failed task can set the exit code using [A.SetExitCode].
+// Use [ExitCode] to customize the exit codes.
+//
+// The first termination signal cancels the flow context
+// and the second one exits the program.
+// Use [InterruptSignals], [InterruptGrace], [InterruptMessages],
+// [OnInterrupt], and [DumpOnQuit] to customize the signal handling.
 //
 // Calls [Usage] when invalid args are provided.
 func Main(args []string, opts ...Option) {
@@ -447,8 +591,17 @@ func Main(args []string, opts ...Option) {
 // It exits the current program after the run is finished
 // or a termination signal interrupted the execution.
 //   - 0 exit code means that none of the tasks failed.
-//   - 1 exit code means that a task has failed or the execution was interrupted.
+//   - 1 exit code means that a task has failed.
 //   - 2 exit code means that the input was invalid.
+//   - 3 exit code means that the execution was interrupted.
+//
+// A failed task can set the exit code using [A.SetExitCode].
+// Use [ExitCode] to customize the exit codes.
+//
+// The first termination signal cancels the flow context
+// and the second one exits the program.
+// Use [InterruptSignals], [InterruptGrace], [InterruptMessages],
+// [OnInterrupt], and [DumpOnQuit] to customize the signal handling.
 //
 // Calls [Usage] when invalid args are provided.
 func (f *Flow) Main(args []string, opts ...Option) {
@@ -456,6 +609,11 @@ func (f *Flow) Main(args []string, opts ...Option) {
 }
 
 func (f *Flow) runMain(args []string, exit func(int), opts ...Option) int {
+	cfg := &config{}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+
 	out := SyncWriter(f.Output())
 	originalOutput := f.output
 	f.output = out
@@ -473,56 +631,114 @@ func (f *Flow) runMain(args []string, exit func(int), opts ...Option) int {
 	// hard-exit behavior on the second signal.
 	const terminationSignalBuffer = 2
 	signals := make(chan os.Signal, terminationSignalBuffer)
-	signal.Notify(signals, internal.TerminationSignals()...)
+	interruptSignals := cfg.interruptSignals
+	if len(interruptSignals) == 0 {
+		interruptSignals = internal.TerminationSignals()
+	}
+	signal.Notify(signals, interruptSignals...)
 
 	done := make(chan struct{})
-	handlerDone := trapTerminationSignals(out, signals, done, cancel, exit)
+	handlerDone := trapTerminationSignals(out, signals, done, cancel, exit, cfg)
 	defer func() {
 		signal.Stop(signals)
 		close(done)
 		<-handlerDone
 	}()
 
+	if quitSignals := internal.QuitSignals(); cfg.dumpOnQuit && len(quitSignals) > 0 {
+		running := newRunningTasks()
+		opts = append(opts, optionFunc(func(c *config) {
+			c.running = running
+		}))
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, quitSignals...)
+		quitDone := make(chan struct{})
+		dumpDone := dumpOnQuit(out, quit, quitDone, running)
+		defer func() {
+			signal.Stop(quit)
+			close(quitDone)
+			<-dumpDone
+		}()
+	}
+
 	return f.main(ctx, args, opts...)
 }
 
-func trapTerminationSignals(out io.Writer, signals <-chan os.Signal, done <-chan struct{}, cancel context.CancelFunc, exit func(int)) <-chan struct{} {
+func trapTerminationSignals(out io.Writer, signals <-chan os.Signal, done <-chan struct{}, cancel context.CancelFunc, exit func(int), cfg *config) <-chan struct{} {
 	handlerDone := make(chan struct{})
 	go func() {
 		defer close(handlerDone)
 
+		var sig os.Signal
 		select {
-		case <-signals: // first signal, cancel context
-			fmt.Fprintln(out, "first termination signal, graceful stop")
+		case sig = <-signals: // first signal, cancel context
+			fmt.Fprintln(out, messageOrDefault(cfg.stopMessage, defaultStopMessage))
 			cancel()
 		case <-done:
 			return
 		}
 
-		select {
-		case <-signals: // second signal, hard exit
-			fmt.Fprintln(out, "second termination signal, exit")
-			exit(exitCodeFail)
-		case <-done:
+		hooksDone := make(chan struct{})
+		go func() {
+			defer close(hooksDone)
+			for _, fn := range cfg.onInterrupt {
+				fn(sig)
+			}
+		}()
+
+		var timeout <-chan time.Time
+		if cfg.interruptGrace > 0 {
+			timer := time.NewTimer(cfg.interruptGrace)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+
+		for done != nil || hooksDone != nil {
+			select {
+			case <-signals: // second signal, hard exit
+				fmt.Fprintln(out, messageOrDefault(cfg.exitMessage, defaultExitMessage))
+				exit(exitCodeInterrupted)
+				return
+			case <-timeout: // grace period expired, hard exit
+				fmt.Fprintln(out, messageOrDefault(cfg.timeoutMessage, defaultTimeoutMessage))
+				exit(exitCodeInterrupted)
+				return
+			case <-done:
+				done = nil
+			case <-hooksDone:
+				hooksDone = nil
+			}
 		}
 	}()
 	return handlerDone
 }
 
 func (f *Flow) main(ctx context.Context, args []string, opts ...Option) int {
-	err := f.Execute(ctx, args, opts...)
-	var ferr *FailError
-	if errors.As(err, &ferr) {
-		return exitCodeFail
+	cfg := &config{}
+	for _, opt := range opts {
+		opt.apply(cfg)
 	}
-	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-		return exitCodeFail
+	var statuses *taskStatuses
+	if cfg.exitCode != nil {
+		statuses = newTaskStatuses()
+		opts = append(opts, optionFunc(func(c *config) {
+			c.statuses = statuses
+		}))
 	}
-	if err != nil {
+
+	err := f.Execute(ctx, args, opts...)
+	code := defaultExitCode(err)
+	if code == exitCodeInvalid {
 		f.Usage()()
-		return exitCodeInvalid
 	}
-	return exitCodePass
+	if cfg.exitCode != nil {
+		code = cfg.exitCode(Outcome{
+			Err:      err,
+			Statuses: statuses.snapshot(),
+			ExitCode: code,
+		})
+	}
+	return code
 }
 
 // Print prints the information about the registered tasks.
@@ -3,6 +3,7 @@ package goyek
 import (
 	"context"
 	"io"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -13,6 +14,18 @@ func TestFlow_main(t *testing.T) {
 	flow.SetOutput(&strings.Builder{})
 	flow.Define(Task{Name: "task"})
 	flow.Define(Task{Name: "failing", Action: func(a *A) { a.Fail() }})
+	flow.Define(Task{Name: "lint", Action: func(a *A) {
+		a.SetExitCode(5)
+		a.Fail()
+	}})
+	flow.Define(Task{Name: "passing-code", Action: func(a *A) { a.SetExitCode(5) }})
+	flow.Define(Task{Name: "subtask", Action: func(a *A) {
+		a.Run("sub", func(a *A) {
+			a.SetExitCode(6)
+			a.Fail()
+		})
+	}})
+	flow.Define(Task{Name: "skipping", Action: func(a *A) { a.Skip() }})
 
 	testCases := []struct {
 		desc string
@@ -29,6 +42,44 @@ func TestFlow_main(t *testing.T) {
 			want: 1,
 			act:  func() int { return flow.main(context.Background(), []string{"failing"}) },
 		},
+		{
+			desc: "task exit code",
+			want: 5,
+			act:  func() int { return flow.main(context.Background(), []string{"lint"}) },
+		},
+		{
+			desc: "passing task exit code",
+			want: 0,
+			act:  func() int { return flow.main(context.Background(), []string{"passing-code"}) },
+		},
+		{
+			desc: "subtask exit code",
+			want: 6,
+			act:  func() int { return flow.main(context.Background(), []string{"subtask"}) },
+		},
+		{
+			desc: "exit code mapping",
+			want: 4,
+			act: func() int {
+				return flow.main(context.Background(), []string{"skipping"}, ExitCode(func(o Outcome) int {
+					for _, status := range o.Statuses {
+						if status != StatusSkipped {
+							return o.ExitCode
+						}
+					}
+					return 4
+				}))
+			},
+		},
+		{
+			desc: "exit code mapping default",
+			want: 5,
+			act: func() int {
+				return flow.main(context.Background(), []string{"lint"}, ExitCode(func(o Outcome) int {
+					return o.ExitCode
+				}))
+			},
+		},
 		{
 			desc: "invalid",
 			want: 2,
@@ -36,13 +87,50 @@ func TestFlow_main(t *testing.T) {
 		},
 		{
 			desc: "canceled",
-			want: 1,
+			want: 3,
 			act: func() int {
 				ctx, cancel := context.WithCancel(context.Background())
 				cancel()
 				return flow.main(ctx, []string{"task"})
 			},
 		},
+		{
+			desc: "interrupted failure",
+			want: 3,
+			act: func() int {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				flow := &Flow{}
+				flow.SetOutput(&strings.Builder{})
+				flow.Define(Task{
+					Name: "task",
+					Action: func(a *A) {
+						cancel()
+						a.Fatal(a.Context().Err())
+					},
+				})
+				return flow.main(ctx, []string{"task"})
+			},
+		},
+		{
+			desc: "timed out",
+			want: 1,
+			act: func() int {
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+				defer cancel()
+				flow := &Flow{}
+				flow.SetOutput(&strings.Builder{})
+				flow.Define(Task{
+					Name: "task",
+					Action: func(a *A) {
+						// The deadline expires while the action is running.
+						<-a.Context().Done()
+						a.Fatal(a.Context().Err())
+					},
+				})
+				return flow.main(ctx, []string{"task"})
+			},
+		},
 		{
 			desc: "interrupted success",
 			want: 0,
@@ -70,6 +158,15 @@ func TestFlow_main(t *testing.T) {
 	}
 }
 
+func TestExitCode_nil(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "nil exit code function" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	ExitCode(nil)
+}
+
 func Test_main_usage(t *testing.T) {
 	flow := &Flow{}
 	flow.SetOutput(io.Discard)
@@ -112,6 +209,20 @@ func TestFlow_runMain(t *testing.T) {
 	}
 }
 
+func TestFlow_runMain_options(t *testing.T) {
+	flow := &Flow{}
+	flow.SetOutput(io.Discard)
+	flow.Define(Task{Name: "task"})
+
+	code := flow.runMain([]string{"task"}, func(code int) {
+		t.Fatalf("exit called with code %d", code)
+	}, InterruptSignals(os.Interrupt), InterruptGrace(time.Second), DumpOnQuit())
+
+	if code != exitCodePass {
+		t.Fatalf("got exit code %d, want %d", code, exitCodePass)
+	}
+}
+
 func TestFlow_runMain_sharesSynchronizedOutput(t *testing.T) {
 	flow := &Flow{}
 	out := io.Discard
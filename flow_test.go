@@ -2,6 +2,7 @@ package goyek_test
 
 import (
 	"context"
+	"errors"
 	"io"
 	"os"
 	"reflect"
@@ -33,6 +34,10 @@ func Test_DefaultFlow(t *testing.T) {
 		goyek.SetLogger(goyek.FmtLogger{})
 		assertEqual(t, goyek.GetLogger(), goyek.FmtLogger{}, "Logger")
 
+		goyek.SetLogLevel(goyek.LevelDebug)
+		assertEqual(t, goyek.GetLogLevel(), goyek.LevelDebug, "LogLevel")
+		goyek.SetLogLevel(goyek.LevelInfo)
+
 		goyek.SetUsage(goyek.Print)
 		goyek.Usage()()
 
@@ -284,6 +289,99 @@ func Test_skip(t *testing.T) {
 	assertTrue(t, skipped, "a.Skipped() should return true")
 }
 
+func Test_condition(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		ok     bool
+		reason string
+		want   goyek.Status
+		output string
+	}{
+		{desc: "met", ok: true, want: goyek.StatusPassed, output: "action\n"},
+		{desc: "not met", reason: "only on plan9", want: goyek.StatusSkipped, output: "      only on plan9\n"},
+		{desc: "not met without reason", want: goyek.StatusSkipped, output: ""},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			out := &strings.Builder{}
+			var got goyek.Status
+			var ctx context.Context
+			var evaluatedBefore bool
+			flow := &goyek.Flow{}
+			flow.SetOutput(out)
+			flow.SetLogger(&goyek.CodeLineLogger{})
+			flow.Use(func(next goyek.Runner) goyek.Runner {
+				return func(in goyek.Input) goyek.Result {
+					evaluatedBefore = ctx != nil
+					res := next(in)
+					got = res.Status
+					return res
+				}
+			})
+			flow.Define(goyek.Task{
+				Name:   "task",
+				Action: func(a *goyek.A) { io.WriteString(a.Output(), "action\n") }, //nolint:errcheck // test
+				Condition: func(c context.Context) (bool, string) {
+					ctx = c
+					return tc.ok, tc.reason
+				},
+			})
+
+			err := flow.Execute(context.Background(), []string{"task"})
+
+			assertPass(t, err, "should pass")
+			assertEqual(t, got, tc.want, "should return proper status")
+			assertEqual(t, out.String(), tc.output, "should print proper output")
+			assertTrue(t, ctx != nil, "should pass the task context")
+			assertTrue(t, evaluatedBefore, "should evaluate the condition before running the task")
+		})
+	}
+}
+
+func Test_condition_logger(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	logger := &skipLogger{}
+	flow.SetLogger(logger)
+	flow.Define(goyek.Task{
+		Name:      "task",
+		Action:    func(*goyek.A) {},
+		Condition: func(context.Context) (bool, string) { return false, "not on CI" },
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, logger.skipped, []interface{}{"not on CI"}, "should pass the reason to Logger.Skip")
+}
+
+type skipLogger struct {
+	goyek.FmtLogger
+	skipped []interface{}
+}
+
+func (l *skipLogger) Skip(_ io.Writer, args ...interface{}) {
+	l.skipped = append(l.skipped, args...)
+}
+
+func Test_condition_noAction(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	called := false
+	flow.Define(goyek.Task{
+		Name: "task",
+		Condition: func(context.Context) (bool, string) {
+			called = true
+			return false, ""
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertPass(t, err, "should pass")
+	assertTrue(t, !called, "should not call the condition for a task without an action")
+}
+
 func Test_task_panics(t *testing.T) {
 	testCases := []struct {
 		desc   string
@@ -349,6 +447,26 @@ func Test_cancelation_during_last_task(t *testing.T) {
 	assertPass(t, err, "should pass as the flow completed")
 }
 
+func Test_cancelation_failing_task(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			cancel()
+			a.Fatal(a.Context().Err())
+		},
+	})
+
+	err := flow.Execute(ctx, []string{"task"})
+
+	assertFail(t, err, "should fail")
+	assertTrue(t, errors.Is(err, context.Canceled), "should unwrap to context canceled")
+	assertEqual(t, err.Error(), "task failed: task: context canceled", "should return proper message")
+}
+
 func Test_empty_action(t *testing.T) {
 	flow := &goyek.Flow{}
 	flow.SetOutput(io.Discard)
@@ -1113,3 +1231,59 @@ func Test_Parallel_concurrent_printing(t *testing.T) {
 	assertContains(t, out, "from 1", "should contain log from task-1")
 	assertContains(t, out, "from 2", "should contain log from task-2")
 }
+
+func Test_deadline_during_action(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		action func(a *goyek.A)
+		want   goyek.Status
+		err    error
+	}{
+		{
+			desc: "fatal",
+			action: func(a *goyek.A) {
+				<-a.Context().Done()
+				a.Fatal(a.Context().Err())
+			},
+			want: goyek.StatusTimedOut,
+			err:  context.DeadlineExceeded,
+		},
+		{
+			desc: "panic",
+			action: func(a *goyek.A) {
+				<-a.Context().Done()
+				panic("panicked")
+			},
+			want: goyek.StatusFailed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			var started bool
+			var got goyek.Status
+			flow := &goyek.Flow{}
+			flow.SetOutput(io.Discard)
+			flow.Use(func(next goyek.Runner) goyek.Runner {
+				return func(in goyek.Input) goyek.Result {
+					started = in.Context.Err() == nil
+					res := next(in)
+					got = res.Status
+					return res
+				}
+			})
+			flow.Define(goyek.Task{Name: "task", Action: tc.action})
+
+			err := flow.Execute(ctx, []string{"task"})
+
+			assertTrue(t, started, "should run the task before the deadline")
+			assertEqual(t, got, tc.want, "should return proper status")
+			var ferr *goyek.FailError
+			if !errors.As(err, &ferr) {
+				t.Fatalf("should return FailError, got: %v", err)
+			}
+			assertEqual(t, ferr.Err, tc.err, "should return proper error")
+		})
+	}
+}
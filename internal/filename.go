@@ -0,0 +1,43 @@
+package internal
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FileName returns name without unusual characters (such as path separators
+// or characters interacting with globs) so that it can be used as a part
+// of a file name. The result is truncated to at most maxLen bytes
+// without splitting multi-byte characters.
+func FileName(name string, maxLen int) string {
+	name = strings.Map(fileNameMapper, name)
+	if len(name) > maxLen {
+		name = truncateUTF8(name[:maxLen])
+	}
+	return name
+}
+
+func fileNameMapper(r rune) rune {
+	if r < utf8.RuneSelf {
+		const allowed = "!#$%&()+,-.=@^_{}~ "
+		if '0' <= r && r <= '9' ||
+			'a' <= r && r <= 'z' ||
+			'A' <= r && r <= 'Z' {
+			return r
+		}
+		if strings.ContainsRune(allowed, r) {
+			return r
+		}
+	} else if unicode.IsLetter(r) || unicode.IsNumber(r) {
+		return r
+	}
+	return -1
+}
+
+func truncateUTF8(s string) string {
+	for len(s) > 0 && !utf8.ValidString(s) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
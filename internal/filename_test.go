@@ -0,0 +1,25 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/goyek/goyek/v3/internal"
+)
+
+func TestFileName(t *testing.T) {
+	tests := []struct {
+		name   string
+		maxLen int
+		want   string
+	}{
+		{name: "build", maxLen: 64, want: "build"},
+		{name: "lint/go:fix *", maxLen: 64, want: "lintgofix "},
+		{name: "zażółć", maxLen: 64, want: "zażółć"},
+		{name: "zażółć", maxLen: 3, want: "za"},
+	}
+	for _, tt := range tests {
+		if got := internal.FileName(tt.name, tt.maxLen); got != tt.want {
+			t.Errorf("FileName(%q, %d) = %q, want %q", tt.name, tt.maxLen, got, tt.want)
+		}
+	}
+}
@@ -9,3 +9,8 @@ import "os"
 func TerminationSignals() []os.Signal {
 	return []os.Signal{os.Interrupt}
 }
+
+// QuitSignals returns signals that should dump the running goroutines.
+func QuitSignals() []os.Signal {
+	return nil
+}
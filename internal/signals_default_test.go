@@ -18,3 +18,9 @@ func TestTerminationSignals(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func TestQuitSignals(t *testing.T) {
+	if got := internal.QuitSignals(); got != nil {
+		t.Fatalf("got %v, want nil", got)
+	}
+}
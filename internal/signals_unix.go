@@ -12,3 +12,8 @@ import (
 func TerminationSignals() []os.Signal {
 	return []os.Signal{os.Interrupt, syscall.SIGTERM}
 }
+
+// QuitSignals returns signals that should dump the running goroutines.
+func QuitSignals() []os.Signal {
+	return []os.Signal{syscall.SIGQUIT}
+}
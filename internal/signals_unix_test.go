@@ -19,3 +19,11 @@ func TestTerminationSignals(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, want)
 	}
 }
+
+func TestQuitSignals(t *testing.T) {
+	got := internal.QuitSignals()
+	want := []os.Signal{syscall.SIGQUIT}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
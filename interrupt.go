@@ -0,0 +1,157 @@
+package goyek
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultStopMessage    = "first termination signal, graceful stop"
+	defaultExitMessage    = "second termination signal, exit"
+	defaultTimeoutMessage = "graceful stop timed out, exit"
+)
+
+// InterruptSignals is an option for [Flow.Main] to set the signals
+// which interrupt the flow execution.
+// By default, SIGINT and SIGTERM are used on Unix-like systems
+// and [os.Interrupt] on other systems.
+func InterruptSignals(signals ...os.Signal) Option {
+	return optionFunc(func(c *config) {
+		c.interruptSignals = signals
+	})
+}
+
+// InterruptGrace is an option for [Flow.Main] to set the time
+// given to the flow to stop after it was interrupted.
+// When the grace period expires, the program exits
+// even if no second signal was received.
+// By default, or if d is zero or negative, there is no time limit.
+func InterruptGrace(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.interruptGrace = d
+	})
+}
+
+// InterruptMessages is an option for [Flow.Main] to set the messages
+// printed when the first signal interrupts the flow execution,
+// when the second signal exits the program, and when the program exits
+// because the grace period set by [InterruptGrace] expired.
+// The default message is used for each empty message.
+func InterruptMessages(stop, exit, timeout string) Option {
+	return optionFunc(func(c *config) {
+		c.stopMessage = stop
+		c.exitMessage = exit
+		c.timeoutMessage = timeout
+	})
+}
+
+// OnInterrupt is an option for [Flow.Main] to register a function
+// called with the signal which interrupted the flow execution.
+// The functions are called one by one in the order they were registered
+// after the flow context is canceled, concurrently with the flow.
+// Main waits for them before returning unless the program exits
+// because of the second signal or the grace period expiration.
+func OnInterrupt(fn func(sig os.Signal)) Option {
+	if fn == nil {
+		panic("nil interrupt hook")
+	}
+	return optionFunc(func(c *config) {
+		c.onInterrupt = append(c.onInterrupt, fn)
+	})
+}
+
+// DumpOnQuit is an option for [Flow.Main] to print the names of the running
+// tasks and the stack traces of all goroutines when SIGQUIT is received.
+// The flow execution continues afterwards.
+// It has no effect on systems without SIGQUIT.
+func DumpOnQuit() Option {
+	return optionFunc(func(c *config) {
+		c.dumpOnQuit = true
+	})
+}
+
+func messageOrDefault(msg, defaultMsg string) string {
+	if msg == "" {
+		return defaultMsg
+	}
+	return msg
+}
+
+// runningTasks tracks the names of the running tasks and subtasks.
+type runningTasks struct {
+	mu    sync.Mutex
+	names map[string]int
+}
+
+func newRunningTasks() *runningTasks {
+	return &runningTasks{names: map[string]int{}}
+}
+
+func (r *runningTasks) middleware(next Runner) Runner {
+	return func(in Input) Result {
+		r.mu.Lock()
+		r.names[in.TaskName]++
+		r.mu.Unlock()
+		defer func() {
+			r.mu.Lock()
+			r.names[in.TaskName]--
+			if r.names[in.TaskName] == 0 {
+				delete(r.names, in.TaskName)
+			}
+			r.mu.Unlock()
+		}()
+		return next(in)
+	}
+}
+
+// list returns the sorted names of the running tasks.
+func (r *runningTasks) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	names := make([]string, 0, len(r.names))
+	for name := range r.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// dumpOnQuit prints the running tasks and the goroutines stack traces
+// each time a signal is received until done is closed.
+func dumpOnQuit(out io.Writer, signals <-chan os.Signal, done <-chan struct{}, running *runningTasks) <-chan struct{} {
+	handlerDone := make(chan struct{})
+	go func() {
+		defer close(handlerDone)
+		for {
+			select {
+			case sig := <-signals:
+				names := "none"
+				if tasks := running.list(); len(tasks) > 0 {
+					names = strings.Join(tasks, ", ")
+				}
+				fmt.Fprintf(out, "%v signal, running tasks: %s\n%s\n", sig, names, stacks())
+			case <-done:
+				return
+			}
+		}
+	}()
+	return handlerDone
+}
+
+// stacks returns the stack traces of all goroutines.
+func stacks() []byte {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return buf[:n]
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
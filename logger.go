@@ -13,7 +13,8 @@ import (
 // Logger methods may be called simultaneously from multiple goroutines.
 // Implementations must synchronize access to any shared mutable state.
 // This concurrency requirement also applies to optional Error, Errorf, Fatal,
-// Fatalf, Skip, Skipf, and Helper methods implemented by the logger. A method
+// Fatalf, Skip, Skipf, Debug, Debugf, Info, Infof, Warn, Warnf, and Helper
+// methods implemented by the logger. A method
 // that receives a writer must finish using it before returning and must not
 // retain it for asynchronous use. To keep a logical log record from being
 // interleaved with other output, format the complete record first and write it
@@ -61,29 +62,43 @@ func (l *CodeLineLogger) Logf(w io.Writer, format string, args ...interface{}) {
 // When printing file and line information, that function will be skipped.
 // Helper may be called simultaneously from multiple goroutines.
 func (l *CodeLineLogger) Helper() {
-	var pc [1]uintptr
-	const skip = 3 // skip: runtime.Callers + CodeLineLogger.Helper + A.Helper
+	const maxWrappers = 8
+	var pc [maxWrappers]uintptr
+	const skip = 2 // skip: runtime.Callers + CodeLineLogger.Helper
 	n := runtime.Callers(skip, pc[:])
-	if n == 0 {
+	if n < 2 {
 		panic("zero callers found")
 	}
 
+	// Mark the caller of A.Helper, skipping the frames
+	// of loggers wrapping this one.
+	i := 1
+	for j := 0; j < n-1; j++ {
+		if pcToName(pc[j]) == "github.com/goyek/goyek/v3.(*A).Helper" {
+			i = j + 1
+			break
+		}
+	}
+
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	if l.helperPCs == nil {
 		l.helperPCs = make(map[uintptr]struct{})
 	}
-	if _, found := l.helperPCs[pc[0]]; !found {
-		l.helperPCs[pc[0]] = struct{}{}
+	if _, found := l.helperPCs[pc[i]]; !found {
+		l.helperPCs[pc[i]] = struct{}{}
 		l.helperNames = nil // map will be recreated next time it is needed
 	}
 }
 
-// decorate prefixes the string with the file and line of the call site
-// and inserts the final newline and indentation spaces for formatting.
+// decorate prefixes the string with the file and line of the call site,
+// if there is a meaningful one, and inserts the final newline and indentation spaces for formatting.
 func (l *CodeLineLogger) decorate(s string) string {
 	const skip = 3
 	frame := l.frameSkip(skip)
+	if frame.PC == 0 {
+		return indent(s)
+	}
 	file := frame.File
 	line := frame.Line
 	if file != "" {
@@ -99,10 +114,14 @@ func (l *CodeLineLogger) decorate(s string) string {
 	if line == 0 {
 		line = 1
 	}
+	return indent(fmt.Sprintf("%s:%d: %s", file, line, s))
+}
+
+// indent inserts the final newline and indentation spaces for formatting.
+func indent(s string) string {
 	buf := &strings.Builder{}
 	// Every line is indented at least 6 spaces.
 	buf.WriteString("      ")
-	fmt.Fprintf(buf, "%s:%d: ", file, line)
 	lines := strings.Split(s, "\n")
 	if l := len(lines); l > 1 && lines[l-1] == "" {
 		lines = lines[:l-1]
@@ -151,8 +170,19 @@ func (l *CodeLineLogger) frameSkip(skip int) runtime.Frame {
 			// We've gone up all the way to the runner calling
 			// the action (so the user must have
 			// called a.Helper from inside that action).
+			if isLibraryFrame(prevFrame) {
+				// The action is goyek's own, such as the one skipping
+				// a task whose condition is not met, so there is
+				// no meaningful location.
+				return runtime.Frame{}
+			}
 			return prevFrame
 		}
+		if isLibraryFrame(frame) {
+			// Skip the frames of goyek, such as A methods
+			// or loggers wrapping this one.
+			continue
+		}
 		// If more helper PCs have been added since we last did the conversion
 		if l.helperNames == nil {
 			l.helperNames = make(map[string]struct{})
@@ -174,3 +204,13 @@ func pcToName(pc uintptr) string {
 	frame, _ := frames.Next()
 	return frame.Function
 }
+
+// isLibraryFrame reports whether the frame belongs to the non-test code
+// of goyek packages.
+func isLibraryFrame(frame runtime.Frame) bool {
+	const module = "github.com/goyek/goyek/v3"
+	if !strings.HasPrefix(frame.Function, module+".") && !strings.HasPrefix(frame.Function, module+"/") {
+		return false
+	}
+	return !strings.HasSuffix(frame.File, "_test.go")
+}
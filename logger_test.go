@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/cmd"
+	"github.com/goyek/goyek/v3/middleware"
 )
 
 func TestCodeLineLogger(t *testing.T) {
@@ -27,9 +29,9 @@ func TestCodeLineLogger(t *testing.T) {
 
 	_ = flow.Execute(context.Background(), []string{"task"})
 
-	assertContains(t, out, "      logger_test.go:20: message", "should contain code line info")
-	assertContains(t, out, "      logger_test.go:21: message from helper", "should respect a.Helper()")
-	assertContains(t, out, "      logger_test.go:23: cleanup", "should respect a.Cleanup()")
+	assertContains(t, out, "      logger_test.go:22: message", "should contain code line info")
+	assertContains(t, out, "      logger_test.go:23: message from helper", "should respect a.Helper()")
+	assertContains(t, out, "      logger_test.go:25: cleanup", "should respect a.Cleanup()")
 }
 
 func TestCodeLineLogger_helper_in_action(t *testing.T) {
@@ -48,10 +50,84 @@ func TestCodeLineLogger_helper_in_action(t *testing.T) {
 
 	_ = flow.Execute(context.Background(), []string{"task"})
 
-	assertContains(t, out, "      logger_test.go:45: message", "should contain code line info")
+	assertContains(t, out, "      logger_test.go:47: message", "should contain code line info")
+}
+
+func TestCodeLineLogger_frames(t *testing.T) {
+	testCases := []struct {
+		desc   string
+		logger goyek.Logger
+		action func(a *goyek.A)
+		want   string
+	}{
+		{
+			desc:   "direct call",
+			logger: &goyek.CodeLineLogger{},
+			action: func(a *goyek.A) {
+				a.Info("message")
+			},
+			want: "      logger_test.go:67: message\n",
+		},
+		{
+			desc:   "nested helpers",
+			logger: &goyek.CodeLineLogger{},
+			action: func(a *goyek.A) {
+				outerHelperFn(a)
+			},
+			want: "      logger_test.go:75: message from helper\n",
+		},
+		{
+			desc:   "helper through wrapping logger",
+			logger: middleware.ColorLogger(&goyek.CodeLineLogger{}, middleware.Palette{}),
+			action: func(a *goyek.A) {
+				helperFn(a)
+			},
+			want: "      logger_test.go:83: message from helper\n",
+		},
+		{
+			desc:   "cmd package",
+			logger: &goyek.CodeLineLogger{},
+			action: func(a *goyek.A) {
+				cmd.Exec(a, "goyek-no-such-program")
+			},
+			want: "      logger_test.go:91: Exec: goyek-no-such-program\n" +
+				"      logger_test.go:91: goyek-no-such-program: ",
+		},
+		{
+			desc:   "goyek action",
+			logger: &goyek.CodeLineLogger{},
+			want:   "      not on CI\n",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flow := &goyek.Flow{}
+			out := &strings.Builder{}
+			flow.SetOutput(out)
+			flow.SetLogger(tc.logger)
+			task := goyek.Task{Name: "task", Action: tc.action}
+			if tc.action == nil {
+				// The action skipping the task is defined by goyek.
+				task.Action = func(*goyek.A) {}
+				task.Condition = func(context.Context) (bool, string) { return false, "not on CI" }
+			}
+			flow.Define(task)
+
+			_ = flow.Execute(context.Background(), []string{"task"})
+
+			if got := out.String(); !strings.HasPrefix(got, tc.want) {
+				t.Errorf("got: %q; should start with: %q", got, tc.want)
+			}
+		})
+	}
 }
 
 func helperFn(a *goyek.A) {
 	a.Helper()
 	a.Log("message from helper")
 }
+
+func outerHelperFn(a *goyek.A) {
+	a.Helper()
+	helperFn(a)
+}
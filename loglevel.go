@@ -0,0 +1,27 @@
+package goyek
+
+import "strconv"
+
+// LogLevel is the minimum level of the messages logged
+// by [A.Debug], [A.Info], [A.Warn], and their variants.
+// Messages logged by the other methods of [A] are always logged.
+type LogLevel int
+
+// Log levels. The zero value is LevelInfo.
+const (
+	LevelDebug LogLevel = -1
+	LevelInfo  LogLevel = 0
+	LevelWarn  LogLevel = 1
+)
+
+func (l LogLevel) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	}
+	return "goyek.LogLevel(" + strconv.Itoa(int(l)) + ")"
+}
@@ -0,0 +1,27 @@
+package goyek_test
+
+import (
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestLogLevel_String(t *testing.T) {
+	testCases := []struct {
+		name string
+		l    goyek.LogLevel
+		want string
+	}{
+		{name: "Debug", l: goyek.LevelDebug, want: "DEBUG"},
+		{name: "Info", l: goyek.LevelInfo, want: "INFO"},
+		{name: "Warn", l: goyek.LevelWarn, want: "WARN"},
+		{name: "Other", l: goyek.LogLevel(5), want: "goyek.LogLevel(5)"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.l.String(); got != tc.want {
+				t.Errorf("LogLevel.String() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package goyek
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+const secretMask = "***"
+
+// MaskSecrets registers secret values which are replaced with "***"
+// in the output of the tasks. Empty values are ignored.
+func MaskSecrets(values ...string) {
+	DefaultFlow.MaskSecrets(values...)
+}
+
+// MaskSecrets registers secret values which are replaced with "***"
+// in the output of the tasks run by [Flow.Execute] and [Flow.Main].
+// The values are masked in [A.Output], so that the writers which
+// middlewares derive from the task output, such as the log files of
+// [github.com/goyek/goyek/v3/middleware.TaskLogs], do not contain them.
+// A value is masked even if it is split across writes of the same task.
+// Empty values are ignored.
+func (f *Flow) MaskSecrets(values ...string) {
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		f.secrets = append(f.secrets, v)
+	}
+}
+
+// MaskEnv registers environment variables whose values
+// are replaced with "***" in the output of the tasks.
+func MaskEnv(names ...string) {
+	DefaultFlow.MaskEnv(names...)
+}
+
+// MaskEnv registers environment variables whose values
+// are masked like the ones registered using [Flow.MaskSecrets].
+// The values are read when the flow execution starts.
+// Unset and empty variables are ignored.
+func (f *Flow) MaskEnv(names ...string) {
+	f.secretEnvs = append(f.secretEnvs, names...)
+}
+
+// secretValues returns the values to mask, the longest first.
+func (f *Flow) secretValues() []string {
+	values := append([]string(nil), f.secrets...)
+	for _, name := range f.secretEnvs {
+		if v := os.Getenv(name); v != "" {
+			values = append(values, v)
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+	return values
+}
+
+// maskWriter replaces the secrets with the mask.
+// Each task has its own maskWriter so that the held back bytes
+// are not joined with the output of other tasks.
+// The trailing bytes which may begin a secret are held back
+// until the next write or flush.
+type maskWriter struct {
+	w       io.Writer
+	secrets [][]byte // the longest first
+
+	mu      sync.Mutex
+	pending []byte
+}
+
+func newMaskWriter(w io.Writer, secrets []string) *maskWriter {
+	mw := &maskWriter{w: w}
+	for _, s := range secrets {
+		mw.secrets = append(mw.secrets, []byte(s))
+	}
+	return mw
+}
+
+func (w *maskWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.pending = append(w.pending, p...)
+	b := w.mask(false)
+	if len(b) == 0 {
+		return len(p), nil
+	}
+	if _, err := w.w.Write(b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// flush writes the held back bytes.
+func (w *maskWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b := w.mask(true)
+	if len(b) == 0 {
+		return
+	}
+	w.w.Write(b) //nolint:errcheck // not checking errors when writing to output
+}
+
+// mask returns the masked pending bytes which can be written.
+// Unless final is true, the bytes which may begin a secret are kept pending.
+func (w *maskWriter) mask(final bool) []byte {
+	buf := w.pending
+	out := make([]byte, 0, len(buf))
+	i := 0
+scan:
+	for i < len(buf) {
+		rest := buf[i:]
+		if !final {
+			for _, s := range w.secrets {
+				if len(rest) < len(s) && bytes.HasPrefix(s, rest) {
+					break scan // wait for more bytes
+				}
+			}
+		}
+		for _, s := range w.secrets {
+			if bytes.HasPrefix(rest, s) {
+				out = append(out, secretMask...)
+				i += len(s)
+				continue scan
+			}
+		}
+		out = append(out, buf[i])
+		i++
+	}
+	w.pending = append([]byte(nil), buf[i:]...)
+	return out
+}
@@ -0,0 +1,115 @@
+package goyek_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func TestFlow_MaskSecrets(t *testing.T) {
+	const envName = "GOYEK_TEST_TOKEN"
+	prev, ok := os.LookupEnv(envName)
+	os.Setenv(envName, "env-secret")
+	defer func() {
+		if ok {
+			os.Setenv(envName, prev)
+		} else {
+			os.Unsetenv(envName)
+		}
+	}()
+
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.MaskSecrets("token", "token-long", "")
+	flow.MaskEnv(envName, "GOYEK_TEST_UNSET")
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			a.Log("token=token token-long-1")
+			a.Log("env=env-secret")
+			w := a.Output()
+			w.Write([]byte("split=tok"))      //nolint:errcheck // test
+			w.Write([]byte("en-lo"))          //nolint:errcheck // test
+			w.Write([]byte("ng end=to"))      //nolint:errcheck // test
+			w.Write([]byte("ke\nprefix=tok")) //nolint:errcheck // test
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertPass(t, err, "should pass")
+	want := "***=*** ***-1\nenv=***\nsplit=*** end=toke\nprefix=tok"
+	assertEqual(t, out.String(), want, "should mask secrets")
+}
+
+func TestFlow_MaskSecrets_TaskLogs(t *testing.T) {
+	dir := t.TempDir()
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.Use(middleware.TaskLogs(dir))
+	flow.MaskSecrets("s3cr3t")
+	flow.Define(goyek.Task{
+		Name: "t",
+		Action: func(a *goyek.A) {
+			a.Log("token=s3cr3t")
+			a.Run("sub", func(a *goyek.A) {
+				a.Output().Write([]byte("sub=s3c")) //nolint:errcheck // test
+				a.Output().Write([]byte("r3t\n"))   //nolint:errcheck // test
+			})
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"t"})
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, out.String(), "token=***\nsub=***\n", "should mask secrets in the output")
+	b, err := os.ReadFile(filepath.Join(dir, "t.log"))
+	requireEqual(t, err, nil, "should read the task log")
+	assertEqual(t, string(b), "token=***\nsub=***\n", "should mask secrets in the task log")
+	matches, err := filepath.Glob(filepath.Join(dir, "tsub-*.log"))
+	requireEqual(t, err, nil, "should find the subtask log")
+	requireEqual(t, len(matches), 1, "should write the subtask log")
+	b, err = os.ReadFile(matches[0])
+	requireEqual(t, err, nil, "should read the subtask log")
+	assertEqual(t, string(b), "sub=***\n", "should mask secrets in the subtask log")
+}
+
+func TestFlow_MaskSecrets_parallel(t *testing.T) {
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.MaskSecrets("token")
+	written := make(chan struct{})
+	flow.Define(goyek.Task{
+		Name:     "first",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			a.Output().Write([]byte("to")) //nolint:errcheck // test
+			close(written)
+		},
+	})
+	flow.Define(goyek.Task{
+		Name:     "second",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			<-written
+			a.Output().Write([]byte("ken\n")) //nolint:errcheck // test
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"first", "second"})
+
+	assertPass(t, err, "should pass")
+	got := out.String()
+	assertTrue(t, !strings.Contains(got, "***"), "should not join the output of different tasks: "+got)
+	assertTrue(t, strings.Contains(got, "ken\n") && strings.Contains(got, "to"), "should write the output of both tasks: "+got)
+}
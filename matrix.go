@@ -0,0 +1,131 @@
+package goyek
+
+import (
+	"sort"
+	"strings"
+)
+
+// matrixSeparators are the characters used in the names
+// of the combination tasks which cannot be used in the matrix.
+const matrixSeparators = "[],="
+
+// Matrix contains the named dimensions of a matrix task
+// and the values of each dimension.
+type Matrix map[string][]string
+
+// DefineMatrix registers a task for each combination of the matrix values
+// and an aggregate task which depends on all of them.
+// It panics in case of any error.
+func DefineMatrix(task Task, matrix Matrix) *DefinedTask {
+	return DefaultFlow.DefineMatrix(task, matrix)
+}
+
+// DefineMatrix registers a task for each combination of the matrix values
+// using the task as a template, and an aggregate task which depends
+// on all of them. The aggregate task is returned.
+//
+// The combination tasks are named like "test[go=1.22,mod=api]",
+// with the dimensions sorted by name, and have no usage.
+// The dimension names and values cannot contain '[', ']', ',', or '=',
+// and the values of a dimension must be unique.
+// Use [A.MatrixValue] to get the values of the running combination.
+// The aggregate task has the template's name and usage, and no action.
+//
+// It panics in case of any error.
+func (f *Flow) DefineMatrix(task Task, matrix Matrix) *DefinedTask {
+	// Validate everything before defining any task
+	// so that the flow is not left partially updated.
+	if task.Name == "" {
+		panic("task name cannot be empty")
+	}
+	if _, ok := f.tasks[task.Name]; ok {
+		panic("task with the same name is already defined")
+	}
+	f.snapshotDeps(task.Deps)
+	copyResources(task.Resources)
+	if len(matrix) == 0 {
+		panic("matrix cannot be empty")
+	}
+	keys := make([]string, 0, len(matrix))
+	for key, values := range matrix {
+		if key == "" {
+			panic("matrix dimension name cannot be empty")
+		}
+		if strings.ContainsAny(key, matrixSeparators) {
+			panic("matrix dimension name cannot contain any of " + matrixSeparators + ": " + key)
+		}
+		if len(values) == 0 {
+			panic("matrix dimension has no values: " + key)
+		}
+		seen := map[string]bool{}
+		for _, v := range values {
+			if strings.ContainsAny(v, matrixSeparators) {
+				panic("matrix value cannot contain any of " + matrixSeparators + ": " + v)
+			}
+			if seen[v] {
+				panic("matrix dimension has duplicate value: " + key + "=" + v)
+			}
+			seen[v] = true
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var combos []map[string]string
+	var build func(i int, combo map[string]string)
+	build = func(i int, combo map[string]string) {
+		if i == len(keys) {
+			c := make(map[string]string, len(combo))
+			for k, v := range combo {
+				c[k] = v
+			}
+			combos = append(combos, c)
+			return
+		}
+		for _, v := range matrix[keys[i]] {
+			combo[keys[i]] = v
+			build(i+1, combo)
+		}
+	}
+	build(0, map[string]string{})
+
+	names := make([]string, len(combos))
+	for i, combo := range combos {
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, key+"="+combo[key])
+		}
+		names[i] = task.Name + "[" + strings.Join(pairs, ",") + "]"
+		if _, ok := f.tasks[names[i]]; ok {
+			panic("task with the same name is already defined: " + names[i])
+		}
+	}
+
+	deps := make(Deps, 0, len(combos))
+	for i, combo := range combos {
+		comboTask := task
+		comboTask.Name = names[i]
+		comboTask.Usage = ""
+		if action := task.Action; action != nil {
+			combo := combo
+			comboTask.Action = func(a *A) {
+				a.matrix = combo
+				action(a)
+			}
+		}
+		deps = append(deps, f.Define(comboTask))
+	}
+
+	return f.Define(Task{
+		Name:  task.Name,
+		Usage: task.Usage,
+		Deps:  deps,
+	})
+}
+
+// MatrixValue returns the value of the matrix dimension
+// for the running task defined by [Flow.DefineMatrix] or its subtasks.
+// It returns an empty string if the task has no such dimension.
+func (a *A) MatrixValue(key string) string {
+	return a.matrix[key]
+}
@@ -0,0 +1,111 @@
+package goyek_test
+
+import (
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestFlow_DefineMatrix(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	var mu sync.Mutex
+	var got []string
+	setup := flow.Define(goyek.Task{Name: "setup"})
+	test := flow.DefineMatrix(goyek.Task{
+		Name:     "test",
+		Usage:    "run tests",
+		Deps:     goyek.Deps{setup},
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			mu.Lock()
+			defer mu.Unlock()
+			got = append(got, a.Name()+" "+a.MatrixValue("go")+" "+a.MatrixValue("mod"))
+		},
+	}, goyek.Matrix{
+		"mod": {"api", "cli"},
+		"go":  {"1.21", "1.22"},
+	})
+
+	assertEqual(t, test.Name(), "test", "aggregate task name")
+	assertEqual(t, test.Usage(), "run tests", "aggregate task usage")
+	var deps []string
+	for _, dep := range test.Deps() {
+		deps = append(deps, dep.Name())
+		assertEqual(t, dep.Usage(), "", "combination task usage")
+		assertEqual(t, dep.Deps(), goyek.Deps{setup}, "combination task deps")
+	}
+	assertEqual(t, deps, []string{
+		"test[go=1.21,mod=api]",
+		"test[go=1.21,mod=cli]",
+		"test[go=1.22,mod=api]",
+		"test[go=1.22,mod=cli]",
+	}, "combination tasks")
+
+	assertPass(t, flow.Execute(context.Background(), []string{"test"}), "should pass")
+
+	sort.Strings(got)
+	assertEqual(t, got, []string{
+		"test[go=1.21,mod=api] 1.21 api",
+		"test[go=1.21,mod=cli] 1.21 cli",
+		"test[go=1.22,mod=api] 1.22 api",
+		"test[go=1.22,mod=cli] 1.22 cli",
+	}, "should run each combination")
+}
+
+func TestFlow_DefineMatrix_subtask(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	var got string
+	flow.DefineMatrix(goyek.Task{
+		Name: "test",
+		Action: func(a *goyek.A) {
+			a.Run("module", func(a *goyek.A) {
+				got = a.MatrixValue("go")
+			})
+		},
+	}, goyek.Matrix{"go": {"1.22"}})
+
+	assertPass(t, flow.Execute(context.Background(), []string{"test"}), "should pass")
+
+	assertEqual(t, got, "1.22", "should pass the matrix value to the subtask")
+}
+
+func TestFlow_DefineMatrix_invalid(t *testing.T) {
+	otherFlow := &goyek.Flow{}
+	otherTask := otherFlow.Define(goyek.Task{Name: "other"})
+	testCases := []struct {
+		desc   string
+		task   goyek.Task
+		matrix goyek.Matrix
+	}{
+		{desc: "empty", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{}},
+		{desc: "empty dimension name", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"": {"a"}}},
+		{desc: "no values", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"os": nil}},
+		{desc: "empty task name", task: goyek.Task{}, matrix: goyek.Matrix{"os": {"linux"}}},
+		{desc: "defined task name", task: goyek.Task{Name: "existing"}, matrix: goyek.Matrix{"os": {"linux"}}},
+		{desc: "defined combination name", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"os": {"linux", "windows"}}},
+		{desc: "invalid dependency", task: goyek.Task{Name: "task", Deps: goyek.Deps{otherTask}}, matrix: goyek.Matrix{"os": {"linux"}}},
+		{desc: "duplicate value", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"go": {"1.22", "1.21", "1.22"}}},
+		{desc: "separator in dimension name", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"go,os": {"1.22"}}},
+		{desc: "comma in value", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"os": {"linux,windows"}}},
+		{desc: "equal sign in value", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"os": {"os=linux"}}},
+		{desc: "bracket in value", task: goyek.Task{Name: "task"}, matrix: goyek.Matrix{"os": {"linux]"}}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			flow := &goyek.Flow{}
+			flow.Define(goyek.Task{Name: "existing"})
+			flow.Define(goyek.Task{Name: "task[os=windows]"})
+
+			act := func() { flow.DefineMatrix(tc.task, tc.matrix) }
+
+			assertPanics(t, act, "should panic")
+			assertEqual(t, len(flow.Tasks()), 2, "should not define any task")
+		})
+	}
+}
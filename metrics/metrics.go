@@ -0,0 +1,251 @@
+// Package metrics provides metrics of task runs.
+//
+// A [Recorder] counts task runs by status
+// and records task duration histograms.
+// The metrics can be written in the Prometheus text exposition format
+// or pushed through a pluggable [Sink] after each flow execution.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// DefaultBuckets are the default upper bounds, in seconds,
+// of the task duration histogram buckets.
+var DefaultBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800}
+
+// Snapshot contains the metrics recorded so far.
+type Snapshot struct {
+	Tasks []TaskMetrics // sorted by name
+}
+
+// TaskMetrics contains the metrics of a task.
+type TaskMetrics struct {
+	Name string
+
+	// Runs contains the number of task runs by status.
+	Runs map[goyek.Status]uint64
+
+	Duration Histogram
+}
+
+// Histogram contains the distribution of task durations in seconds.
+type Histogram struct {
+	Buckets []float64 // upper bounds
+	Counts  []uint64  // cumulative counts for each bucket
+	Count   uint64
+	Sum     float64
+}
+
+// Sink receives the metrics after each flow execution.
+type Sink interface {
+	Push(ctx context.Context, snapshot Snapshot) error
+}
+
+// SinkFunc is an adapter to allow the use of an ordinary function as a [Sink].
+type SinkFunc func(ctx context.Context, snapshot Snapshot) error
+
+// Push calls fn(ctx, snapshot).
+func (fn SinkFunc) Push(ctx context.Context, snapshot Snapshot) error {
+	return fn(ctx, snapshot)
+}
+
+// FileSink returns a Sink which writes the metrics
+// in the Prometheus text exposition format to the file.
+// The file is replaced atomically and is readable by all users,
+// so it can be consumed by a Prometheus node exporter textfile collector.
+func FileSink(path string) Sink {
+	return SinkFunc(func(_ context.Context, snapshot Snapshot) error {
+		f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name()) //nolint:errcheck // the file is already renamed in case of success
+		if err := snapshot.WritePrometheus(f); err != nil {
+			f.Close()
+			return err
+		}
+		if err := f.Close(); err != nil {
+			return err
+		}
+		// The temporary file is only readable by the owner.
+		if err := os.Chmod(f.Name(), 0o644); err != nil { //nolint:gosec // the metrics are not secret
+			return err
+		}
+		return os.Rename(f.Name(), path)
+	})
+}
+
+// Recorder records the metrics of task runs.
+//
+// Use [Recorder.Runner] with [goyek.Flow.Use] to record task runs.
+// Use [Recorder.Executor] with [goyek.Flow.UseExecutor] to push the metrics
+// to the sink after each flow execution.
+//
+// A Recorder is safe for concurrent use.
+type Recorder struct {
+	sink    Sink
+	buckets []float64
+
+	mu    sync.Mutex
+	tasks map[string]*TaskMetrics
+}
+
+// NewRecorder returns a Recorder which pushes the metrics to the sink.
+// The sink can be nil. The buckets are the upper bounds, in seconds,
+// of the task duration histogram. [DefaultBuckets] are used if none are given.
+func NewRecorder(sink Sink, buckets ...float64) *Recorder {
+	if len(buckets) == 0 {
+		buckets = DefaultBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+	return &Recorder{
+		sink:    sink,
+		buckets: buckets,
+		tasks:   map[string]*TaskMetrics{},
+	}
+}
+
+// Executor is a flow executor middleware which pushes
+// the metrics to the sink after the flow execution.
+func (r *Recorder) Executor(next goyek.Executor) goyek.Executor {
+	return func(in goyek.ExecuteInput) error {
+		err := next(in)
+
+		if r.sink == nil {
+			return err
+		}
+		ctx := in.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if pushErr := r.sink.Push(ctx, r.Snapshot()); err == nil {
+			err = pushErr
+		}
+		return err
+	}
+}
+
+// Runner is a task runner middleware which records the task run.
+func (r *Recorder) Runner(next goyek.Runner) goyek.Runner {
+	return func(in goyek.Input) goyek.Result {
+		start := time.Now()
+		res := goyek.Result{Status: goyek.StatusFailed}
+		defer func() {
+			r.record(in.TaskName, res.Status, time.Since(start))
+		}()
+		res = next(in)
+		return res
+	}
+}
+
+func (r *Recorder) record(name string, status goyek.Status, d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.tasks[name]
+	if !ok {
+		m = &TaskMetrics{
+			Name: name,
+			Runs: map[goyek.Status]uint64{},
+			Duration: Histogram{
+				Buckets: r.buckets,
+				Counts:  make([]uint64, len(r.buckets)),
+			},
+		}
+		r.tasks[name] = m
+	}
+	m.Runs[status]++
+
+	seconds := d.Seconds()
+	m.Duration.Count++
+	m.Duration.Sum += seconds
+	for i, bound := range m.Duration.Buckets {
+		if seconds <= bound {
+			m.Duration.Counts[i]++
+		}
+	}
+}
+
+// Snapshot returns a copy of the metrics recorded so far.
+func (r *Recorder) Snapshot() Snapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var snapshot Snapshot
+	for _, m := range r.tasks {
+		task := *m
+		task.Runs = make(map[goyek.Status]uint64, len(m.Runs))
+		for status, count := range m.Runs {
+			task.Runs[status] = count
+		}
+		task.Duration.Buckets = append([]float64(nil), m.Duration.Buckets...)
+		task.Duration.Counts = append([]uint64(nil), m.Duration.Counts...)
+		snapshot.Tasks = append(snapshot.Tasks, task)
+	}
+	sort.Slice(snapshot.Tasks, func(i, j int) bool { return snapshot.Tasks[i].Name < snapshot.Tasks[j].Name })
+	return snapshot
+}
+
+// WritePrometheus writes the metrics recorded so far
+// in the Prometheus text exposition format.
+func (r *Recorder) WritePrometheus(w io.Writer) error {
+	return r.Snapshot().WritePrometheus(w)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (s Snapshot) WritePrometheus(w io.Writer) error {
+	sb := &strings.Builder{}
+
+	sb.WriteString("# HELP goyek_task_runs_total Number of task runs by status.\n")
+	sb.WriteString("# TYPE goyek_task_runs_total counter\n")
+	for _, task := range s.Tasks {
+		statuses := make([]goyek.Status, 0, len(task.Runs))
+		for status := range task.Runs {
+			statuses = append(statuses, status)
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+		for _, status := range statuses {
+			fmt.Fprintf(sb, "goyek_task_runs_total{task=%s,status=%s} %d\n",
+				quote(task.Name), quote(strings.ToLower(status.String())), task.Runs[status])
+		}
+	}
+
+	sb.WriteString("# HELP goyek_task_duration_seconds Duration of task runs.\n")
+	sb.WriteString("# TYPE goyek_task_duration_seconds histogram\n")
+	for _, task := range s.Tasks {
+		name := quote(task.Name)
+		h := task.Duration
+		for i, bound := range h.Buckets {
+			fmt.Fprintf(sb, "goyek_task_duration_seconds_bucket{task=%s,le=%s} %d\n",
+				name, quote(formatFloat(bound)), h.Counts[i])
+		}
+		fmt.Fprintf(sb, "goyek_task_duration_seconds_bucket{task=%s,le=\"+Inf\"} %d\n", name, h.Count)
+		fmt.Fprintf(sb, "goyek_task_duration_seconds_sum{task=%s} %s\n", name, formatFloat(h.Sum))
+		fmt.Fprintf(sb, "goyek_task_duration_seconds_count{task=%s} %d\n", name, h.Count)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// quote returns the label value escaped and quoted
+// according to the Prometheus text exposition format.
+func quote(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+	return `"` + s + `"`
+}
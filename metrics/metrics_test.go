@@ -0,0 +1,124 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/metrics"
+)
+
+func TestRecorder(t *testing.T) {
+	var pushed []metrics.Snapshot
+	sink := metrics.SinkFunc(func(_ context.Context, snapshot metrics.Snapshot) error {
+		pushed = append(pushed, snapshot)
+		return nil
+	})
+	recorder := metrics.NewRecorder(sink, 10, 1)
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.UseExecutor(recorder.Executor)
+	flow.Use(recorder.Runner)
+	flow.Define(goyek.Task{Name: "pass", Action: func(*goyek.A) {}})
+	flow.Define(goyek.Task{Name: "skip", Action: func(a *goyek.A) { a.Skip() }})
+	flow.Define(goyek.Task{Name: "fail", Action: func(a *goyek.A) { a.Fail() }})
+
+	_ = flow.Execute(context.Background(), []string{"pass", "skip"})
+	_ = flow.Execute(context.Background(), []string{"pass", "fail"})
+
+	if len(pushed) != 2 {
+		t.Fatalf("got %d pushes, want 2", len(pushed))
+	}
+	sb := &strings.Builder{}
+	if err := recorder.WritePrometheus(sb); err != nil {
+		t.Fatal(err)
+	}
+	got := sb.String()
+	for _, want := range []string{
+		"# TYPE goyek_task_runs_total counter\n",
+		`goyek_task_runs_total{task="pass",status="pass"} 2` + "\n",
+		`goyek_task_runs_total{task="skip",status="skip"} 1` + "\n",
+		`goyek_task_runs_total{task="fail",status="fail"} 1` + "\n",
+		"# TYPE goyek_task_duration_seconds histogram\n",
+		`goyek_task_duration_seconds_bucket{task="pass",le="1"} 2` + "\n",
+		`goyek_task_duration_seconds_bucket{task="pass",le="10"} 2` + "\n",
+		`goyek_task_duration_seconds_bucket{task="pass",le="+Inf"} 2` + "\n",
+		`goyek_task_duration_seconds_count{task="fail"} 1` + "\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got:\n%s\nshould contain: %q", got, want)
+		}
+	}
+}
+
+func TestRecorder_pushError(t *testing.T) {
+	pushErr := errors.New("push failed")
+	recorder := metrics.NewRecorder(metrics.SinkFunc(func(context.Context, metrics.Snapshot) error {
+		return pushErr
+	}))
+	executor := recorder.Executor(func(goyek.ExecuteInput) error { return nil })
+
+	if err := executor(goyek.ExecuteInput{}); err != pushErr {
+		t.Errorf("got %v, want the push error", err)
+	}
+}
+
+func TestSnapshot_WritePrometheus_escapes(t *testing.T) {
+	snapshot := metrics.Snapshot{Tasks: []metrics.TaskMetrics{{
+		Name: "a\"b\\c\nd",
+		Runs: map[goyek.Status]uint64{goyek.StatusPassed: 1},
+	}}}
+	sb := &strings.Builder{}
+
+	if err := snapshot.WritePrometheus(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := `{task="a\"b\\c\nd",status="pass"} 1`; !strings.Contains(sb.String(), want) {
+		t.Errorf("got:\n%s\nshould contain: %q", sb.String(), want)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "goyek.prom")
+	recorder := metrics.NewRecorder(metrics.FileSink(path))
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.UseExecutor(recorder.Executor)
+	flow.Use(recorder.Runner)
+	flow.Define(goyek.Task{Name: "task", Action: func(*goyek.A) {}})
+
+	if err := flow.Execute(context.Background(), []string{"task"}); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `goyek_task_runs_total{task="task",status="pass"} 1`; !strings.Contains(string(b), want) {
+		t.Errorf("got:\n%s\nshould contain: %q", b, want)
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := info.Mode().Perm(); got != 0o644 {
+			t.Errorf("got mode %v, want %v", got, os.FileMode(0o644))
+		}
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("temporary files should be removed, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// ChromeTrace records the task execution timeline and writes it in the
+// Chrome Trace Event Format, which can be viewed using Perfetto
+// or chrome://tracing.
+//
+// Use [ChromeTrace.Executor] with [goyek.Flow.UseExecutor] and
+// [ChromeTrace.Runner] with [goyek.Flow.Use]. Each running task is placed on
+// the lowest free lane, so tasks running in parallel are displayed on
+// separate lanes. All flow executions, for example the ones of
+// [goyek.Flow.Watch], are recorded on the same timeline.
+// Use [ChromeTrace.Write] to write the trace.
+//
+// A ChromeTrace is safe for concurrent use.
+type ChromeTrace struct {
+	mu     sync.Mutex
+	start  time.Time
+	events []traceEvent
+	lanes  []bool // lanes in use
+}
+
+type traceEvent struct {
+	Name     string            `json:"name"`
+	Category string            `json:"cat,omitempty"`
+	Phase    string            `json:"ph"`
+	Time     int64             `json:"ts"`
+	Duration int64             `json:"dur"`
+	PID      int               `json:"pid"`
+	TID      int               `json:"tid"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
+// NewChromeTrace returns a ChromeTrace.
+func NewChromeTrace() *ChromeTrace {
+	return &ChromeTrace{}
+}
+
+// Executor is a flow executor middleware which records the flow execution.
+func (t *ChromeTrace) Executor(next goyek.Executor) goyek.Executor {
+	return func(in goyek.ExecuteInput) error {
+		start := time.Now()
+		t.mu.Lock()
+		if t.start.IsZero() {
+			t.start = start
+		}
+		t.mu.Unlock()
+
+		err := next(in)
+
+		status := "ok"
+		if err != nil {
+			status = err.Error()
+		}
+		t.mu.Lock()
+		t.record("flow", "flow", 0, start, time.Now(), map[string]string{"result": status})
+		t.mu.Unlock()
+		return err
+	}
+}
+
+// Runner is a task runner middleware which records the task run.
+func (t *ChromeTrace) Runner(next goyek.Runner) goyek.Runner {
+	return func(in goyek.Input) goyek.Result {
+		lane := t.acquireLane()
+		start := time.Now()
+		res := goyek.Result{Status: goyek.StatusFailed}
+		defer func() {
+			args := map[string]string{"status": res.Status.String()}
+			if in.Parallel {
+				args["parallel"] = "true"
+			}
+			t.mu.Lock()
+			t.lanes[lane] = false
+			t.record(in.TaskName, "task", lane+1, start, time.Now(), args)
+			t.mu.Unlock()
+		}()
+		res = next(in)
+		return res
+	}
+}
+
+func (t *ChromeTrace) acquireLane() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, busy := range t.lanes {
+		if !busy {
+			t.lanes[i] = true
+			return i
+		}
+	}
+	t.lanes = append(t.lanes, true)
+	return len(t.lanes) - 1
+}
+
+// record must be called with t.mu held.
+func (t *ChromeTrace) record(name, category string, tid int, start, end time.Time, args map[string]string) {
+	if t.start.IsZero() {
+		t.start = start
+	}
+	t.events = append(t.events, traceEvent{
+		Name:     name,
+		Category: category,
+		Phase:    "X",
+		Time:     start.Sub(t.start).Microseconds(),
+		Duration: end.Sub(start).Microseconds(),
+		PID:      1,
+		TID:      tid,
+		Args:     args,
+	})
+}
+
+// Write writes the trace of the recorded flow executions to w
+// as a single JSON document.
+func (t *ChromeTrace) Write(w io.Writer) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	events := []traceEvent{
+		{Name: "process_name", Phase: "M", PID: 1, Args: map[string]string{"name": "goyek"}},
+		{Name: "thread_name", Phase: "M", PID: 1, TID: 0, Args: map[string]string{"name": "flow"}},
+	}
+	for i := range t.lanes {
+		events = append(events, traceEvent{
+			Name:  "thread_name",
+			Phase: "M",
+			PID:   1,
+			TID:   i + 1,
+			Args:  map[string]string{"name": "lane " + strconv.Itoa(i+1)},
+		})
+	}
+	events = append(events, t.events...)
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
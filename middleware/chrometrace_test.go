@@ -0,0 +1,137 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func TestChromeTrace(t *testing.T) {
+	trace := middleware.NewChromeTrace()
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.UseExecutor(trace.Executor)
+	flow.Use(trace.Runner)
+
+	// Make sure that both parallel tasks are running at the same time.
+	var started sync.WaitGroup
+	started.Add(2)
+	parallel := func(a *goyek.A) {
+		started.Done()
+		started.Wait()
+	}
+	p1 := flow.Define(goyek.Task{Name: "p1", Parallel: true, Action: parallel})
+	p2 := flow.Define(goyek.Task{Name: "p2", Parallel: true, Action: parallel})
+	flow.Define(goyek.Task{Name: "last", Deps: goyek.Deps{p1, p2}, Action: func(a *goyek.A) { a.Fail() }})
+
+	err := flow.Execute(context.Background(), []string{"last"})
+	if err == nil {
+		t.Fatal("should fail")
+	}
+	sb := &strings.Builder{}
+	if err := trace.Write(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		TraceEvents []struct {
+			Name  string            `json:"name"`
+			Phase string            `json:"ph"`
+			TID   int               `json:"tid"`
+			Args  map[string]string `json:"args"`
+		} `json:"traceEvents"`
+	}
+	if err := json.Unmarshal([]byte(sb.String()), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, sb.String())
+	}
+	lanes := map[string]int{}
+	statuses := map[string]string{}
+	for _, ev := range got.TraceEvents {
+		if ev.Phase != "X" {
+			continue
+		}
+		lanes[ev.Name] = ev.TID
+		statuses[ev.Name] = ev.Args["status"]
+	}
+	if lanes["flow"] != 0 {
+		t.Errorf("flow should be on lane 0, got %d", lanes["flow"])
+	}
+	if lanes["p1"] == lanes["p2"] {
+		t.Errorf("parallel tasks should be on different lanes, got %v", lanes)
+	}
+	if lanes["last"] != 1 {
+		t.Errorf("task should reuse the first free lane, got %d", lanes["last"])
+	}
+	if statuses["last"] != "FAIL" || statuses["p1"] != "PASS" {
+		t.Errorf("got statuses %v", statuses)
+	}
+}
+
+func TestChromeTrace_multipleExecutions(t *testing.T) {
+	trace := middleware.NewChromeTrace()
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.UseExecutor(trace.Executor)
+	flow.Use(trace.Runner)
+	flow.Define(goyek.Task{Name: "task", Action: func(*goyek.A) {}})
+
+	for i := 0; i < 2; i++ {
+		if err := flow.Execute(context.Background(), []string{"task"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sb := &strings.Builder{}
+	if err := trace.Write(sb); err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		TraceEvents []struct {
+			Name  string `json:"name"`
+			Phase string `json:"ph"`
+			Time  int64  `json:"ts"`
+		} `json:"traceEvents"`
+	}
+	dec := json.NewDecoder(strings.NewReader(sb.String()))
+	if err := dec.Decode(&got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, sb.String())
+	}
+	if dec.More() {
+		t.Fatalf("should write a single document, got:\n%s", sb.String())
+	}
+	var flows, tasks []int64
+	for _, ev := range got.TraceEvents {
+		switch {
+		case ev.Phase == "X" && ev.Name == "flow":
+			flows = append(flows, ev.Time)
+		case ev.Phase == "X" && ev.Name == "task":
+			tasks = append(tasks, ev.Time)
+		}
+	}
+	if len(flows) != 2 || len(tasks) != 2 {
+		t.Fatalf("should record both executions, got flows %v and tasks %v", flows, tasks)
+	}
+	if flows[1] < flows[0] {
+		t.Errorf("executions should be on the same timeline, got %v", flows)
+	}
+}
+
+func TestChromeTrace_writeError(t *testing.T) {
+	trace := middleware.NewChromeTrace()
+
+	if err := trace.Write(errWriter{}); err == nil {
+		t.Error("should return the write error")
+	}
+}
+
+type errWriter struct{}
+
+func (errWriter) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
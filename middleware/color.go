@@ -0,0 +1,345 @@
+package middleware
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// Palette contains the ANSI escape sequences used to colorize reports.
+// The zero value disables colorizing.
+type Palette struct {
+	Pass   string // passed task status
+	Fail   string // failed task status
+	Skip   string // skipped task status
+	NotRun string // not run task status
+	Error  string // error messages
+	Warn   string // warning messages
+	Debug  string // debug messages
+	Reset  string // resets the formatting
+}
+
+// ColorPalette is the palette using the default ANSI colors.
+var ColorPalette = Palette{
+	Pass:   "\x1b[32m",   // green
+	Fail:   "\x1b[31m",   // red
+	Skip:   "\x1b[33m",   // yellow
+	NotRun: "\x1b[2m",    // dim
+	Error:  "\x1b[1;31m", // bold red
+	Warn:   "\x1b[33m",   // yellow
+	Debug:  "\x1b[2m",    // dim
+	Reset:  "\x1b[0m",
+}
+
+// AutoPalette returns [ColorPalette] if [ColorEnabled] reports
+// that w supports colors and the zero Palette otherwise.
+func AutoPalette(w io.Writer) Palette {
+	if ColorEnabled(w) {
+		return ColorPalette
+	}
+	return Palette{}
+}
+
+// ColorEnabled reports whether the output written to w should be colorized.
+//
+// Setting the NO_COLOR environment variable to a non-empty value disables
+// colors. Otherwise, setting the FORCE_COLOR environment variable to a value
+// other than "0" or "false" enables colors. Otherwise, colors are enabled only
+// if [IsTerminal] reports that w is a terminal.
+//
+// Pass the writer configured for the flow, such as [os.Stdout], as the output
+// received by middlewares does not expose the underlying writer.
+func ColorEnabled(w io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	if force, ok := os.LookupEnv("FORCE_COLOR"); ok {
+		return force != "0" && force != "false"
+	}
+	return IsTerminal(w)
+}
+
+// IsTerminal reports whether w is an [*os.File] referring to a terminal
+// which supports cursor movement, that is TERM is not "dumb".
+func IsTerminal(w io.Writer) bool {
+	if os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+func (p Palette) status(s goyek.Status) string {
+	var color string
+	switch s {
+	case goyek.StatusPassed:
+		color = p.Pass
+	case goyek.StatusFailed, goyek.StatusCanceled, goyek.StatusTimedOut:
+		color = p.Fail
+	case goyek.StatusSkipped:
+		color = p.Skip
+	case goyek.StatusNotRun:
+		color = p.NotRun
+	}
+	return p.colorize(color, s.String())
+}
+
+func (p Palette) colorize(color, s string) string {
+	if color == "" {
+		return s
+	}
+	return color + s + p.Reset
+}
+
+// ColorLogger returns a logger which highlights the messages logged by
+// [goyek.A.Error], [goyek.A.Errorf], [goyek.A.Fatal], and [goyek.A.Fatalf]
+// using the palette's Error color, by [goyek.A.Warn] and [goyek.A.Warnf]
+// using the Warn color, and by [goyek.A.Debug] and [goyek.A.Debugf]
+// using the Debug color.
+// Other calls are passed to logger unchanged.
+func ColorLogger(logger goyek.Logger, p Palette) goyek.Logger {
+	return colorLogger{logger, p}
+}
+
+type colorLogger struct {
+	goyek.Logger
+	palette Palette
+}
+
+func (l colorLogger) Error(w io.Writer, args ...interface{}) {
+	w = l.highlight(w, l.palette.Error)
+	if inner, ok := l.Logger.(interface {
+		Error(w io.Writer, args ...interface{})
+	}); ok {
+		inner.Error(w, args...)
+		return
+	}
+	l.Logger.Log(w, args...)
+}
+
+func (l colorLogger) Errorf(w io.Writer, format string, args ...interface{}) {
+	w = l.highlight(w, l.palette.Error)
+	if inner, ok := l.Logger.(interface {
+		Errorf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		inner.Errorf(w, format, args...)
+		return
+	}
+	l.Logger.Logf(w, format, args...)
+}
+
+func (l colorLogger) Fatal(w io.Writer, args ...interface{}) {
+	w = l.highlight(w, l.palette.Error)
+	if inner, ok := l.Logger.(interface {
+		Fatal(w io.Writer, args ...interface{})
+	}); ok {
+		inner.Fatal(w, args...)
+		return
+	}
+	l.Logger.Log(w, args...)
+}
+
+func (l colorLogger) Fatalf(w io.Writer, format string, args ...interface{}) {
+	w = l.highlight(w, l.palette.Error)
+	if inner, ok := l.Logger.(interface {
+		Fatalf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		inner.Fatalf(w, format, args...)
+		return
+	}
+	l.Logger.Logf(w, format, args...)
+}
+
+func (l colorLogger) Skip(w io.Writer, args ...interface{}) {
+	if inner, ok := l.Logger.(interface {
+		Skip(w io.Writer, args ...interface{})
+	}); ok {
+		inner.Skip(w, args...)
+		return
+	}
+	l.Logger.Log(w, args...)
+}
+
+func (l colorLogger) Skipf(w io.Writer, format string, args ...interface{}) {
+	if inner, ok := l.Logger.(interface {
+		Skipf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		inner.Skipf(w, format, args...)
+		return
+	}
+	l.Logger.Logf(w, format, args...)
+}
+
+func (l colorLogger) Debug(w io.Writer, args ...interface{}) {
+	w = l.highlight(w, l.palette.Debug)
+	if inner, ok := l.Logger.(interface {
+		Debug(w io.Writer, args ...interface{})
+	}); ok {
+		inner.Debug(w, args...)
+		return
+	}
+	l.Logger.Log(w, args...)
+}
+
+func (l colorLogger) Debugf(w io.Writer, format string, args ...interface{}) {
+	w = l.highlight(w, l.palette.Debug)
+	if inner, ok := l.Logger.(interface {
+		Debugf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		inner.Debugf(w, format, args...)
+		return
+	}
+	l.Logger.Logf(w, format, args...)
+}
+
+func (l colorLogger) Info(w io.Writer, args ...interface{}) {
+	if inner, ok := l.Logger.(interface {
+		Info(w io.Writer, args ...interface{})
+	}); ok {
+		inner.Info(w, args...)
+		return
+	}
+	l.Logger.Log(w, args...)
+}
+
+func (l colorLogger) Infof(w io.Writer, format string, args ...interface{}) {
+	if inner, ok := l.Logger.(interface {
+		Infof(w io.Writer, format string, args ...interface{})
+	}); ok {
+		inner.Infof(w, format, args...)
+		return
+	}
+	l.Logger.Logf(w, format, args...)
+}
+
+func (l colorLogger) Warn(w io.Writer, args ...interface{}) {
+	w = l.highlight(w, l.palette.Warn)
+	if inner, ok := l.Logger.(interface {
+		Warn(w io.Writer, args ...interface{})
+	}); ok {
+		inner.Warn(w, args...)
+		return
+	}
+	l.Logger.Log(w, args...)
+}
+
+func (l colorLogger) Warnf(w io.Writer, format string, args ...interface{}) {
+	w = l.highlight(w, l.palette.Warn)
+	if inner, ok := l.Logger.(interface {
+		Warnf(w io.Writer, format string, args ...interface{})
+	}); ok {
+		inner.Warnf(w, format, args...)
+		return
+	}
+	l.Logger.Logf(w, format, args...)
+}
+
+func (l colorLogger) Helper() {
+	if h, ok := l.Logger.(interface {
+		Helper()
+	}); ok {
+		h.Helper()
+	}
+}
+
+func (l colorLogger) highlight(w io.Writer, color string) io.Writer {
+	if color == "" {
+		return w
+	}
+	return &colorWriter{w: w, color: color, reset: l.palette.Reset}
+}
+
+// colorWriter colorizes each write, keeping the final new line uncolored,
+// so that a log record is still written with one call.
+type colorWriter struct {
+	w     io.Writer
+	color string
+	reset string
+}
+
+func (w *colorWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	if n == 0 {
+		return 0, nil
+	}
+	text := p
+	var newline []byte
+	if p[n-1] == '\n' {
+		text = p[:n-1]
+		newline = p[n-1:]
+	}
+	b := make([]byte, 0, len(w.color)+n+len(w.reset))
+	b = append(b, w.color...)
+	b = append(b, text...)
+	b = append(b, w.reset...)
+	b = append(b, newline...)
+	if _, err := w.w.Write(b); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// StripColor returns a writer which removes ANSI escape sequences
+// before writing to w. Escape sequences split across writes are removed too.
+// It is useful when colorized output is also written to files.
+//
+// The returned writer is safe for concurrent use.
+func StripColor(w io.Writer) io.Writer {
+	return &stripWriter{w: w}
+}
+
+type stripWriter struct {
+	w io.Writer
+
+	mu    sync.Mutex
+	state int
+}
+
+const (
+	stripText   = iota // outside of an escape sequence
+	stripEscape        // after ESC
+	stripCSI           // inside of a control sequence
+)
+
+func (w *stripWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b := make([]byte, 0, len(p))
+	for _, c := range p {
+		switch w.state {
+		case stripText:
+			if c == '\x1b' {
+				w.state = stripEscape
+				continue
+			}
+			b = append(b, c)
+		case stripEscape:
+			if c == '[' {
+				w.state = stripCSI
+			} else {
+				w.state = stripText
+			}
+		case stripCSI:
+			if c >= 0x40 && c <= 0x7e {
+				w.state = stripText
+			}
+		}
+	}
+
+	if len(b) == 0 {
+		return len(p), nil
+	}
+	if _, err := w.w.Write(b); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
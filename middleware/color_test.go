@@ -0,0 +1,150 @@
+package middleware_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func setenv(t *testing.T, key, value string, set bool) {
+	t.Helper()
+	prev, ok := os.LookupEnv(key)
+	if set {
+		os.Setenv(key, value)
+	} else {
+		os.Unsetenv(key)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, prev)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+func TestColorEnabled(t *testing.T) {
+	tests := []struct {
+		name    string
+		noColor string
+		force   string
+		setForc bool
+		want    bool
+	}{
+		{name: "not a terminal", want: false},
+		{name: "force", force: "1", setForc: true, want: true},
+		{name: "force disabled", force: "0", setForc: true, want: false},
+		{name: "no color", noColor: "1", force: "1", setForc: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setenv(t, "NO_COLOR", tt.noColor, tt.noColor != "")
+			setenv(t, "FORCE_COLOR", tt.force, tt.setForc)
+
+			got := middleware.ColorEnabled(&strings.Builder{})
+
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if p := middleware.AutoPalette(&strings.Builder{}); (p == middleware.ColorPalette) != tt.want {
+				t.Errorf("got palette %q", p)
+			}
+		})
+	}
+}
+
+func TestReportStatusColor(t *testing.T) {
+	sb := &strings.Builder{}
+	p := middleware.Palette{Pass: "<pass>", Fail: "<fail>", Error: "<err>", Reset: "</>"}
+	r := middleware.ReportStatusColor(p)(func(goyek.Input) goyek.Result {
+		return goyek.Result{Status: goyek.StatusFailed, PanicValue: "oops", PanicStack: []byte("stack")}
+	})
+
+	r(goyek.Input{TaskName: "task", Output: goyek.SyncWriter(sb)})
+
+	got := sb.String()
+	for _, want := range []string{"----- <fail>FAIL</>: task", "<err>panic: oops</>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got: %q; should contain: %q", got, want)
+		}
+	}
+}
+
+func TestReportFlowColor(t *testing.T) {
+	sb := &strings.Builder{}
+	p := middleware.Palette{Pass: "<pass>", Reset: "</>"}
+	executor := middleware.ReportFlowColor(p)(func(goyek.ExecuteInput) error { return nil })
+
+	_ = executor(goyek.ExecuteInput{Output: goyek.SyncWriter(sb)})
+
+	if want := "<pass>ok</>\t"; !strings.HasPrefix(sb.String(), want) {
+		t.Errorf("got: %q; should start with: %q", sb.String(), want)
+	}
+}
+
+func TestReportLongRunColor(t *testing.T) {
+	sb := &strings.Builder{}
+	p := middleware.Palette{Warn: "<warn>", Reset: "</>"}
+	r := goyek.NewRunner(func(*goyek.A) { time.Sleep(30 * time.Millisecond) })
+	r = middleware.ReportLongRunColor(time.Millisecond, p)(r)
+
+	r(goyek.Input{TaskName: "task", Output: goyek.SyncWriter(sb)})
+
+	if want := "<warn>***** LONG: task ("; !strings.Contains(sb.String(), want) {
+		t.Errorf("got: %q; should contain: %q", sb.String(), want)
+	}
+}
+
+func TestColorLogger(t *testing.T) {
+	sb := &strings.Builder{}
+	flow := &goyek.Flow{}
+	flow.SetOutput(sb)
+	p := middleware.Palette{Error: "<err>", Warn: "<warn>", Reset: "</>"}
+	flow.SetLogger(middleware.ColorLogger(&goyek.CodeLineLogger{}, p))
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			a.Log("info")
+			helper(a)
+			a.Warn("warn")
+		},
+	})
+
+	_ = flow.Execute(context.Background(), []string{"task"})
+
+	got := sb.String()
+	for _, want := range []string{
+		"      color_test.go:112: info\n",
+		"<err>      color_test.go:113: error</>\n",
+		"<warn>      color_test.go:114: warn</>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got: %q; should contain: %q", got, want)
+		}
+	}
+}
+
+func helper(a *goyek.A) {
+	a.Helper()
+	a.Error("error")
+}
+
+func TestStripColor(t *testing.T) {
+	sb := &strings.Builder{}
+	w := middleware.StripColor(sb)
+
+	for _, s := range []string{"\x1b[1;3", "1mred\x1b", "[0m plain\x1b[", "2K\n"} {
+		if n, err := w.Write([]byte(s)); n != len(s) || err != nil {
+			t.Fatalf("Write(%q) = %d, %v", s, n, err)
+		}
+	}
+
+	if got, want := sb.String(), "red plain\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
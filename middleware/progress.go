@@ -0,0 +1,347 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/goyek/goyek/v3"
+)
+
+const (
+	progressRedraw       = 100 * time.Millisecond
+	progressLastLineSize = 60 // in runes
+)
+
+var progressSpinner = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// Progress displays the progress of a flow execution: the number of
+// completed tasks out of the total and, for each running task, the elapsed
+// time and the last line of its output.
+//
+// If the output is interactive, the progress view is redrawn in place below
+// the task output, and each running task has a spinner.
+// Otherwise, a plain status line is written periodically,
+// which makes [ReportLongRun] unnecessary.
+//
+// The output of parallel tasks is buffered and written after the task
+// finishes, like [BufferParallel] does, so it is not needed either.
+//
+// Use [Progress.Executor] with [goyek.Flow.UseExecutor] and
+// [Progress.Runner] with [goyek.Flow.Use]. The runner middleware should be
+// the last one used, so that the output of other middlewares,
+// such as [ReportStatus], is buffered together with the task output.
+//
+// A Progress is safe for concurrent use.
+type Progress struct {
+	flow        *goyek.Flow
+	interactive bool
+	interval    time.Duration
+
+	mu      sync.Mutex
+	out     io.Writer       // nil when the flow is not executing
+	planned map[string]bool // tasks which the flow execution would run
+	total   int
+	done    int
+	running []*progressTask
+	drawn   int    // number of lines of the drawn progress view
+	pending []byte // incomplete line written while the view is drawn
+	frame   int
+}
+
+type progressTask struct {
+	name  string
+	start time.Time
+	last  string // last non-empty line of output
+}
+
+// NewProgress returns a Progress for the flow's execution.
+// The flow is used to count the tasks to run.
+// Pass interactive as true if the flow output is a terminal,
+// for example using [IsTerminal] with [os.Stdout].
+func NewProgress(flow *goyek.Flow, interactive bool) *Progress {
+	return &Progress{
+		flow:        flow,
+		interactive: interactive,
+		interval:    10 * time.Second,
+	}
+}
+
+// SetInterval sets the interval at which the status line is written
+// if the output is not interactive. It defaults to 10 seconds.
+func (p *Progress) SetInterval(d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.interval = d
+}
+
+// Executor is a flow executor middleware which displays the progress
+// until the flow execution finishes.
+func (p *Progress) Executor(next goyek.Executor) goyek.Executor {
+	return func(in goyek.ExecuteInput) error {
+		p.mu.Lock()
+		p.out = outputOrDiscard(in.Output)
+		p.planned = p.plan(in)
+		p.total = len(p.planned)
+		p.done = 0
+		p.running = nil
+		p.drawn = 0
+		p.pending = nil
+		interval := p.interval
+		p.mu.Unlock()
+		in.Output = progressWriter{p}
+
+		if p.interactive {
+			interval = progressRedraw
+		}
+		done := make(chan struct{})
+		var wg sync.WaitGroup
+		if interval > 0 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				p.tick(interval, done)
+			}()
+		}
+
+		err := next(in)
+
+		close(done)
+		wg.Wait()
+		p.mu.Lock()
+		p.clear()
+		p.out.Write(p.pending) //nolint:errcheck // not checking errors when writing to output
+		p.out = nil
+		p.pending = nil
+		p.mu.Unlock()
+		return err
+	}
+}
+
+// Runner is a task runner middleware which tracks the running task.
+func (p *Progress) Runner(next goyek.Runner) goyek.Runner {
+	return func(in goyek.Input) goyek.Result {
+		task := &progressTask{name: in.TaskName, start: time.Now()}
+		p.mu.Lock()
+		p.running = append(p.running, task)
+		p.mu.Unlock()
+
+		out := outputOrDiscard(in.Output)
+		var buf *strings.Builder
+		if in.Parallel {
+			buf = &strings.Builder{}
+			in.Output = &progressTaskWriter{p: p, task: task, w: goyek.SyncWriter(buf)}
+		} else {
+			in.Output = &progressTaskWriter{p: p, task: task, w: out}
+		}
+
+		defer func() {
+			p.mu.Lock()
+			for i, t := range p.running {
+				if t == task {
+					p.running = append(p.running[:i], p.running[i+1:]...)
+					break
+				}
+			}
+			if p.planned[task.name] {
+				// Subtasks run by A.Run are not counted.
+				p.done++
+			}
+			p.mu.Unlock()
+			if buf != nil {
+				io.WriteString(out, buf.String()) //nolint:errcheck // not checking errors when writing to output
+			}
+		}()
+		return next(in)
+	}
+}
+
+// plan returns the tasks which the flow execution would run.
+func (p *Progress) plan(in goyek.ExecuteInput) map[string]bool {
+	deps := map[string][]string{}
+	if p.flow != nil {
+		for _, task := range p.flow.Tasks() {
+			for _, dep := range task.Deps() {
+				deps[task.Name()] = append(deps[task.Name()], dep.Name())
+			}
+		}
+	}
+	visited := map[string]bool{}
+	for _, name := range in.SkipTasks {
+		visited[name] = true
+	}
+	planned := map[string]bool{}
+	var visit func(name string)
+	visit = func(name string) {
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		planned[name] = true
+		if in.NoDeps {
+			return
+		}
+		for _, dep := range deps[name] {
+			visit(dep)
+		}
+	}
+	for _, name := range in.Tasks {
+		visit(name)
+	}
+	return planned
+}
+
+func (p *Progress) tick(interval time.Duration, done <-chan struct{}) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-t.C:
+			p.mu.Lock()
+			if p.interactive {
+				p.frame++
+				p.clear()
+				p.draw()
+			} else if len(p.running) > 0 {
+				io.WriteString(p.out, p.statusLine()) //nolint:errcheck // not checking errors when writing to output
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// statusLine returns the plain status line.
+func (p *Progress) statusLine() string {
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "***** PROGRESS: %d/%d done", p.done, p.total)
+	for i, task := range p.running {
+		if i == 0 {
+			sb.WriteString(", running: ")
+		} else {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(sb, "%s (%s)", task.name, elapsed(task.start))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// draw writes the interactive progress view. It must be called with p.mu held.
+func (p *Progress) draw() {
+	if p.out == nil {
+		return
+	}
+	sb := &strings.Builder{}
+	spinner := progressSpinner[p.frame%len(progressSpinner)]
+	for _, task := range p.running {
+		fmt.Fprintf(sb, "%s %s (%s)", spinner, task.name, elapsed(task.start))
+		if task.last != "" {
+			sb.WriteString(": ")
+			sb.WriteString(task.last)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(sb, "[%d/%d] done\n", p.done, p.total)
+	io.WriteString(p.out, sb.String()) //nolint:errcheck // not checking errors when writing to output
+	p.drawn = len(p.running) + 1
+}
+
+// clear erases the interactive progress view. It must be called with p.mu held.
+func (p *Progress) clear() {
+	if p.drawn == 0 || p.out == nil {
+		return
+	}
+	fmt.Fprintf(p.out, "\x1b[%dA\r\x1b[J", p.drawn) //nolint:errcheck // not checking errors when writing to output
+	p.drawn = 0
+}
+
+func elapsed(start time.Time) string {
+	return fmt.Sprintf("%.1fs", time.Since(start).Seconds())
+}
+
+// progressWriter writes the flow output above the interactive progress view.
+type progressWriter struct {
+	p *Progress
+}
+
+func (w progressWriter) Write(b []byte) (int, error) {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.out == nil {
+		return len(b), nil
+	}
+	if !p.interactive {
+		return p.out.Write(b)
+	}
+
+	// Only complete lines are written, so that the view
+	// is always drawn at the beginning of a line.
+	p.pending = append(p.pending, b...)
+	i := bytes.LastIndexByte(p.pending, '\n')
+	if i < 0 {
+		return len(b), nil
+	}
+	lines := p.pending[:i+1]
+	p.pending = append([]byte(nil), p.pending[i+1:]...)
+	p.clear()
+	if _, err := p.out.Write(lines); err != nil {
+		return 0, err
+	}
+	p.draw()
+	return len(b), nil
+}
+
+// progressTaskWriter tracks the last line of the task output.
+type progressTaskWriter struct {
+	p    *Progress
+	task *progressTask
+	w    io.Writer
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+func (w *progressTaskWriter) Write(b []byte) (int, error) {
+	w.mu.Lock()
+	w.partial = append(w.partial, b...)
+	if i := bytes.LastIndexByte(w.partial, '\n'); i >= 0 {
+		if last := lastLine(w.partial[:i]); last != "" {
+			w.p.mu.Lock()
+			w.task.last = last
+			w.p.mu.Unlock()
+		}
+		w.partial = append([]byte(nil), w.partial[i+1:]...)
+	}
+	w.mu.Unlock()
+	return w.w.Write(b)
+}
+
+// lastLine returns the last non-empty line of b without ANSI escape sequences
+// truncated to fit the progress view.
+func lastLine(b []byte) string {
+	sb := &strings.Builder{}
+	StripColor(sb).Write(b) //nolint:errcheck // strings.Builder never returns an error
+	lines := strings.Split(sb.String(), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if j := strings.LastIndexByte(line, '\r'); j >= 0 && j < len(line)-1 {
+			line = line[j+1:] // the line was overwritten
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if utf8.RuneCountInString(line) > progressLastLineSize {
+			line = string([]rune(line)[:progressLastLineSize-3]) + "..."
+		}
+		return line
+	}
+	return ""
+}
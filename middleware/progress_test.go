@@ -0,0 +1,123 @@
+package middleware_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func progressFlow(out *strings.Builder, interactive bool) (*goyek.Flow, *middleware.Progress) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	progress := middleware.NewProgress(flow, interactive)
+	flow.UseExecutor(progress.Executor)
+	flow.Use(middleware.ReportStatus, progress.Runner)
+
+	dep := flow.Define(goyek.Task{
+		Name:     "dep",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			a.Log("first")
+			time.Sleep(300 * time.Millisecond)
+			a.Log("second")
+		},
+	})
+	flow.Define(goyek.Task{
+		Name:     "other",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			a.Log("other output")
+			time.Sleep(300 * time.Millisecond)
+		},
+	})
+	flow.Define(goyek.Task{
+		Name: "task",
+		Deps: goyek.Deps{dep},
+		Action: func(a *goyek.A) {
+			a.Log("task output")
+		},
+	})
+	flow.Define(goyek.Task{Name: "unused"})
+	return flow, progress
+}
+
+func TestProgress_interactive(t *testing.T) {
+	out := &strings.Builder{}
+	flow, _ := progressFlow(out, true)
+
+	if err := flow.Execute(context.Background(), []string{"task", "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.String()
+	for _, want := range []string{
+		" dep (",
+		"): first\n",
+		" other (",
+		"): other output\n",
+		"[0/3] done\n",
+		"\x1b[J",
+		"===== TASK  dep\nfirst\nsecond\n----- PASS: dep",
+		"\x1b[Jtask output\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got: %q; should contain: %q", got, want)
+		}
+	}
+	if !strings.HasSuffix(got, "\x1b[J") {
+		t.Errorf("progress view should be cleared at the end, got: %q", got)
+	}
+}
+
+func TestProgress_plain(t *testing.T) {
+	out := &strings.Builder{}
+	flow, progress := progressFlow(out, false)
+	progress.SetInterval(100 * time.Millisecond)
+
+	if err := flow.Execute(context.Background(), []string{"task", "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := out.String()
+	for _, want := range []string{"***** PROGRESS: 0/3 done, running: ", "dep (", "other ("} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got: %q; should contain: %q", got, want)
+		}
+	}
+	if strings.Contains(got, "\x1b") {
+		t.Errorf("should not contain escape sequences, got: %q", got)
+	}
+	if want := "===== TASK  other\nother output\n----- PASS: other"; !strings.Contains(got, want) {
+		t.Errorf("got: %q; should contain: %q", got, want)
+	}
+}
+
+func TestProgress_partialLine(t *testing.T) {
+	out := &strings.Builder{}
+	progress := middleware.NewProgress(nil, true)
+	executor := progress.Executor(func(in goyek.ExecuteInput) error {
+		runner := progress.Runner(func(in goyek.Input) goyek.Result {
+			in.Output.Write([]byte("partial")) //nolint:errcheck // test
+			time.Sleep(250 * time.Millisecond)
+			in.Output.Write([]byte(" line\nlast")) //nolint:errcheck // test
+			return goyek.Result{Status: goyek.StatusPassed}
+		})
+		runner(goyek.Input{TaskName: "task", Output: in.Output})
+		return nil
+	})
+
+	_ = executor(goyek.ExecuteInput{Tasks: []string{"task"}, Output: out})
+
+	got := out.String()
+	if want := "partial line\n"; !strings.Contains(got, want) {
+		t.Errorf("got: %q; should contain: %q", got, want)
+	}
+	if want := "\x1b[J" + "last"; !strings.HasSuffix(got, "last") || !strings.Contains(got, want) {
+		t.Errorf("got: %q; should end with the cleared view and: %q", got, "last")
+	}
+}
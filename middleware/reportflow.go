@@ -11,16 +11,24 @@ import (
 //
 // The format is based on the reports provided by the Go test runner.
 func ReportFlow(next goyek.Executor) goyek.Executor {
-	return func(in goyek.ExecuteInput) error {
-		out := outputOrDiscard(in.Output)
-		in.Output = out
+	return ReportFlowColor(Palette{})(next)
+}
+
+// ReportFlowColor returns a middleware which reports the flow execution status
+// like [ReportFlow] using the palette to colorize the result.
+func ReportFlowColor(p Palette) goyek.ExecutorMiddleware {
+	return func(next goyek.Executor) goyek.Executor {
+		return func(in goyek.ExecuteInput) error {
+			out := outputOrDiscard(in.Output)
+			in.Output = out
 
-		from := time.Now()
-		if err := next(in); err != nil {
-			fmt.Fprintf(out, "%v\t%.3fs\n", err, time.Since(from).Seconds())
-			return err
+			from := time.Now()
+			if err := next(in); err != nil {
+				fmt.Fprintf(out, "%s\t%.3fs\n", p.colorize(p.Fail, err.Error()), time.Since(from).Seconds())
+				return err
+			}
+			fmt.Fprintf(out, "%s\t%.3fs\n", p.colorize(p.Pass, "ok"), time.Since(from).Seconds())
+			return nil
 		}
-		fmt.Fprintf(out, "ok\t%.3fs\n", time.Since(from).Seconds())
-		return nil
 	}
 }
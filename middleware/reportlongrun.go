@@ -15,6 +15,12 @@ import (
 // adapt a writer that does not provide its own synchronization. A nil output
 // is replaced with [io.Discard].
 func ReportLongRun(d time.Duration) func(next goyek.Runner) goyek.Runner {
+	return ReportLongRunColor(d, Palette{})
+}
+
+// ReportLongRunColor returns a middleware which reports the task when it is
+// long running like [ReportLongRun] using the palette's Warn color.
+func ReportLongRunColor(d time.Duration, p Palette) func(next goyek.Runner) goyek.Runner {
 	return func(next goyek.Runner) goyek.Runner {
 		return func(in goyek.Input) goyek.Result {
 			out := in.Output
@@ -43,7 +49,8 @@ func ReportLongRun(d time.Duration) func(next goyek.Runner) goyek.Runner {
 					case <-done:
 						return
 					case <-t.C:
-						fmt.Fprintf(out, "***** LONG: %s (%.2fs)\n", task, time.Since(start).Seconds())
+						msg := fmt.Sprintf("***** LONG: %s (%.2fs)", task, time.Since(start).Seconds())
+						io.WriteString(out, p.colorize(p.Warn, msg)+"\n") //nolint:errcheck // not checking errors when writing to output
 					}
 				}
 			}()
@@ -3,43 +3,72 @@ package middleware
 import (
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/goyek/goyek/v3"
 )
 
-// ReportStatus is a middleware which reports the task run status.
+// ReportStatus is a middleware which reports the task run status
+// and the attributes set using [goyek.A.SetAttr].
 //
 // The format is based on the reports provided by the Go test runner.
 func ReportStatus(next goyek.Runner) goyek.Runner {
-	return func(in goyek.Input) goyek.Result {
-		out := outputOrDiscard(in.Output)
-		in.Output = out
-
-		// report start task
-		fmt.Fprintf(out, "===== TASK  %s\n", in.TaskName)
-		start := time.Now()
-
-		// run
-		res := next(in)
-
-		// report task end
-		fmt.Fprintf(out, "----- %s: %s (%.2fs)\n", res.Status, in.TaskName, time.Since(start).Seconds())
-
-		// report panic if happened
-		if res.PanicStack != nil {
-			var report strings.Builder
-			if res.PanicValue != nil {
-				fmt.Fprintf(&report, "panic: %v", res.PanicValue)
-			} else {
-				report.WriteString("panic(nil) or runtime.Goexit() called")
+	return ReportStatusColor(Palette{})(next)
+}
+
+// ReportStatusColor returns a middleware which reports the task run status
+// like [ReportStatus] using the palette to colorize the status.
+func ReportStatusColor(p Palette) goyek.Middleware {
+	return func(next goyek.Runner) goyek.Runner {
+		return func(in goyek.Input) goyek.Result {
+			out := outputOrDiscard(in.Output)
+			in.Output = out
+
+			// report start task
+			fmt.Fprintf(out, "===== TASK  %s\n", in.TaskName)
+			start := time.Now()
+
+			// run
+			res := next(in)
+
+			// report task end
+			fmt.Fprintf(out, "----- %s: %s (%.2fs)\n", p.status(res.Status), in.TaskName, time.Since(start).Seconds())
+			if len(res.Attrs) > 0 {
+				io.WriteString(out, formatAttrs(res.Attrs)) //nolint:errcheck // not checking errors when writing to output
 			}
-			report.WriteString("\n\n")
-			report.Write(res.PanicStack)
-			io.WriteString(out, report.String()) //nolint:errcheck // not checking errors when writing to output
+
+			// report panic if happened
+			if res.PanicStack != nil {
+				var report strings.Builder
+				if res.PanicValue != nil {
+					report.WriteString(p.colorize(p.Error, fmt.Sprintf("panic: %v", res.PanicValue)))
+				} else {
+					report.WriteString(p.colorize(p.Error, "panic(nil) or runtime.Goexit() called"))
+				}
+				report.WriteString("\n\n")
+				report.Write(res.PanicStack)
+				io.WriteString(out, report.String()) //nolint:errcheck // not checking errors when writing to output
+			}
+
+			return res
 		}
+	}
+}
 
-		return res
+// formatAttrs returns the indented line with the attributes.
+// Values are quoted if needed.
+func formatAttrs(attrs []goyek.Attr) string {
+	sb := &strings.Builder{}
+	sb.WriteString("     ")
+	for _, attr := range attrs {
+		v := fmt.Sprint(attr.Value)
+		if v == "" || strings.ContainsAny(v, " =\"\t\n") {
+			v = strconv.Quote(v)
+		}
+		fmt.Fprintf(sb, " %s=%s", attr.Key, v)
 	}
+	sb.WriteString("\n")
+	return sb.String()
 }
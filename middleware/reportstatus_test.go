@@ -31,6 +31,16 @@ func TestReportStatus(t *testing.T) {
 			status: goyek.StatusSkipped,
 			want:   "SKIP: " + taskName,
 		},
+		{
+			name:   "Canceled",
+			status: goyek.StatusCanceled,
+			want:   "CANCEL: " + taskName,
+		},
+		{
+			name:   "TimedOut",
+			status: goyek.StatusTimedOut,
+			want:   "TIMEOUT: " + taskName,
+		},
 		{
 			name:   "NotRun",
 			status: goyek.StatusNotRun,
@@ -101,3 +111,23 @@ func TestReportStatus_nilOutput(t *testing.T) {
 		t.Fatalf("next runner received %T output, want io.Discard", gotOutput)
 	}
 }
+
+func TestReportStatus_attrs(t *testing.T) {
+	sb := &strings.Builder{}
+	r := middleware.ReportStatus(func(goyek.Input) goyek.Result {
+		return goyek.Result{
+			Status: goyek.StatusPassed,
+			Attrs: []goyek.Attr{
+				{Key: "coverage", Value: 83.4},
+				{Key: "artifact", Value: "dist/my app.tar.gz"},
+			},
+		}
+	})
+
+	r(goyek.Input{TaskName: "task", Output: goyek.SyncWriter(sb)})
+
+	want := "\n      coverage=83.4 artifact=\"dist/my app.tar.gz\"\n"
+	if !strings.HasSuffix(sb.String(), want) {
+		t.Errorf("got: %q; should end with: %q", sb.String(), want)
+	}
+}
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// TaskColors are the default ANSI colors used by [StreamParallelColor]
+// to distinguish the tasks.
+var TaskColors = []string{
+	"\x1b[36m", // cyan
+	"\x1b[33m", // yellow
+	"\x1b[32m", // green
+	"\x1b[35m", // magenta
+	"\x1b[34m", // blue
+	"\x1b[96m", // bright cyan
+	"\x1b[93m", // bright yellow
+	"\x1b[92m", // bright green
+	"\x1b[95m", // bright magenta
+	"\x1b[94m", // bright blue
+}
+
+// StreamParallel is a middleware which streams the output from parallel tasks
+// line by line, prefixing each line with the task name, to not have mixed
+// output from parallel tasks execution. An incomplete last line is written
+// when the task finishes.
+func StreamParallel(next goyek.Runner) goyek.Runner {
+	return StreamParallelColor(nil)(next)
+}
+
+// StreamParallelColor returns a middleware which streams the output from
+// parallel tasks like [StreamParallel] and colors the task name prefixes.
+// The colors are assigned to the tasks in the order in which they start,
+// cycling through the given ones, for example [TaskColors].
+// Use [AutoPalette] or [ColorEnabled] to check if colors should be used.
+func StreamParallelColor(colors []string) func(next goyek.Runner) goyek.Runner {
+	var mu sync.Mutex
+	assigned := map[string]string{}
+	color := func(task string) string {
+		if len(colors) == 0 {
+			return ""
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		c, ok := assigned[task]
+		if !ok {
+			c = colors[len(assigned)%len(colors)]
+			assigned[task] = c
+		}
+		return c
+	}
+
+	return func(next goyek.Runner) goyek.Runner {
+		return func(in goyek.Input) goyek.Result {
+			if !in.Parallel {
+				return next(in)
+			}
+
+			prefix := in.TaskName + " | "
+			if c := color(in.TaskName); c != "" {
+				prefix = c + in.TaskName + ColorPalette.Reset + " | "
+			}
+			w := &prefixWriter{w: outputOrDiscard(in.Output), prefix: []byte(prefix)}
+			in.Output = w
+
+			defer w.flush()
+			return next(in)
+		}
+	}
+}
+
+// prefixWriter writes complete lines prefixed with the prefix.
+// Each line is written with a single call.
+type prefixWriter struct {
+	w      io.Writer
+	prefix []byte
+
+	mu      sync.Mutex
+	partial []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		if err := w.writeLine(w.partial[:i+1]); err != nil {
+			return 0, err
+		}
+		w.partial = w.partial[i+1:]
+	}
+	w.partial = append([]byte(nil), w.partial...)
+	return len(p), nil
+}
+
+func (w *prefixWriter) flush() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.partial) == 0 {
+		return
+	}
+	w.writeLine(append(w.partial, '\n')) //nolint:errcheck // not checking errors when writing to output
+	w.partial = nil
+}
+
+func (w *prefixWriter) writeLine(line []byte) error {
+	b := make([]byte, 0, len(w.prefix)+len(line))
+	b = append(b, w.prefix...)
+	b = append(b, line...)
+	_, err := w.w.Write(b)
+	return err
+}
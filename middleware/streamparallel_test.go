@@ -0,0 +1,72 @@
+package middleware_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func TestStreamParallel(t *testing.T) {
+	out := &strings.Builder{}
+	flow := &goyek.Flow{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.Use(middleware.StreamParallel)
+	flow.Define(goyek.Task{
+		Name:     "task-1",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			a.Log("Hello\nWorld")
+			a.Output().Write([]byte("partial")) //nolint:errcheck // test
+		},
+	})
+	flow.Define(goyek.Task{
+		Name:     "task-2",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			a.Output().Write([]byte("Hi ")) //nolint:errcheck // test
+			a.Log("there")
+		},
+	})
+	flow.Define(goyek.Task{
+		Name: "task-3",
+		Action: func(a *goyek.A) {
+			a.Log("not parallel")
+		},
+	})
+
+	_ = flow.Execute(context.Background(), []string{"task-1", "task-2", "task-3"})
+
+	got := out.String()
+	for _, want := range []string{
+		"task-1 | Hello\n",
+		"task-1 | World\n",
+		"task-1 | partial\n",
+		"task-2 | Hi there\n",
+		"\nnot parallel\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("got: %q; should contain: %q", got, want)
+		}
+	}
+}
+
+func TestStreamParallelColor(t *testing.T) {
+	out := &strings.Builder{}
+	r := middleware.StreamParallelColor([]string{"<1>", "<2>"})(func(in goyek.Input) goyek.Result {
+		in.Output.Write([]byte("line\n")) //nolint:errcheck // test
+		return goyek.Result{}
+	})
+
+	for _, name := range []string{"a", "b", "c", "a"} {
+		r(goyek.Input{TaskName: name, Parallel: true, Output: out})
+	}
+
+	want := "<1>a\x1b[0m | line\n<2>b\x1b[0m | line\n<1>c\x1b[0m | line\n<1>a\x1b[0m | line\n"
+	if got := out.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/internal"
+)
+
+const maxLogFileTaskNameLen = 200
+
+// TaskLogs returns a middleware which writes the output of each task
+// to the <dir>/<task>.log file in addition to the task output.
+// Unusual characters, such as path separators, are dropped from the task name
+// the same way as for [goyek.A.TempDir]. If the file name differs from
+// the task name, a short hash of the task name is appended so that
+// tasks such as "lint/go" and "lintgo" do not share a file.
+// ANSI escape sequences are removed.
+// The directory is created if needed and the log file is overwritten
+// each time the task runs.
+//
+// Use it before [SilentNonFailed] to keep the full output in the log files
+// while printing only the output of failed tasks:
+//
+//	flow.Use(middleware.ReportStatus, middleware.TaskLogs(dir), middleware.SilentNonFailed)
+func TaskLogs(dir string) goyek.Middleware {
+	return func(next goyek.Runner) goyek.Runner {
+		return func(in goyek.Input) goyek.Result {
+			out := outputOrDiscard(in.Output)
+			in.Output = out
+
+			f, err := createTaskLog(dir, in.TaskName)
+			if err != nil {
+				fmt.Fprintf(out, "cannot create task log file: %v\n", err) //nolint:errcheck // not checking errors when writing to output
+				return next(in)
+			}
+			defer f.Close() //nolint:errcheck // the file is only written to
+
+			in.Output = io.MultiWriter(out, StripColor(f))
+			return next(in)
+		}
+	}
+}
+
+func createTaskLog(dir, task string) (*os.File, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	name := internal.FileName(task, maxLogFileTaskNameLen)
+	if name != task {
+		if name == "" {
+			name = "task"
+		}
+		sum := sha256.Sum256([]byte(task))
+		name += "-" + hex.EncodeToString(sum[:4])
+	}
+	return os.Create(filepath.Join(dir, name+".log"))
+}
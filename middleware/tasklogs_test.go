@@ -0,0 +1,122 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func TestTaskLogs(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	out := &strings.Builder{}
+	flow := &goyek.Flow{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.Use(middleware.ReportStatus, middleware.TaskLogs(dir), middleware.SilentNonFailed)
+	flow.Define(goyek.Task{
+		Name: "lint/go",
+		Action: func(a *goyek.A) {
+			a.Log("\x1b[32mpassed\x1b[0m")
+		},
+	})
+	flow.Define(goyek.Task{
+		Name: "test",
+		Action: func(a *goyek.A) {
+			a.Error("failed")
+		},
+	})
+
+	_ = flow.Execute(context.Background(), []string{"lint/go", "test"})
+
+	b, err := os.ReadFile(filepath.Join(dir, "lintgo-"+nameHash("lint/go")+".log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); !strings.HasPrefix(got, "===== TASK  lint/go\npassed\n----- PASS: lint/go") {
+		t.Errorf("got log: %q", got)
+	}
+	b, err = os.ReadFile(filepath.Join(dir, "test.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); !strings.Contains(got, "failed\n----- FAIL: test") {
+		t.Errorf("got log: %q", got)
+	}
+	if got := out.String(); strings.Contains(got, "passed") || !strings.Contains(got, "failed") {
+		t.Errorf("console should show only failed task output, got: %q", got)
+	}
+}
+
+func TestTaskLogs_nameCollision(t *testing.T) {
+	dir := t.TempDir()
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.Use(middleware.TaskLogs(dir))
+	for _, name := range []string{"lint/go", "lintgo", "lint:go"} {
+		name := name
+		flow.Define(goyek.Task{
+			Name: name,
+			Action: func(a *goyek.A) {
+				a.Log("output of " + name)
+			},
+		})
+	}
+
+	if err := flow.Execute(context.Background(), []string{"lint/go", "lintgo", "lint:go"}); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"lint/go": "lintgo-" + nameHash("lint/go") + ".log",
+		"lintgo":  "lintgo.log",
+		"lint:go": "lintgo-" + nameHash("lint:go") + ".log",
+	}
+	for task, file := range files {
+		b, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); got != "output of "+task+"\n" {
+			t.Errorf("got log of %s: %q", task, got)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != len(files) {
+		t.Errorf("got %d log files, want %d", len(entries), len(files))
+	}
+}
+
+func nameHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:4])
+}
+
+func TestTaskLogs_error(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out := &strings.Builder{}
+	r := middleware.TaskLogs(file)(goyek.NewRunner(func(a *goyek.A) { a.Log("message") }))
+
+	res := r(goyek.Input{TaskName: "task", Output: out, Logger: goyek.FmtLogger{}})
+
+	if res.Status != goyek.StatusPassed {
+		t.Errorf("got status %v, the task should still run", res.Status)
+	}
+	if got := out.String(); !strings.HasPrefix(got, "cannot create task log file: ") || !strings.HasSuffix(got, "message\n") {
+		t.Errorf("got: %q", got)
+	}
+}
@@ -18,7 +18,7 @@ func SilentNonFailed(next goyek.Runner) goyek.Runner {
 
 		result := next(in)
 
-		if result.Status == goyek.StatusFailed {
+		if result.Status.Failed() {
 			io.WriteString(originalOut, streamWriter.String()) //nolint:errcheck // not checking errors when writing to output
 		}
 
@@ -26,6 +26,22 @@ func TestSilentNonFailed_failed(t *testing.T) {
 	}
 }
 
+func TestSilentNonFailed_canceled(t *testing.T) {
+	msg := "message"
+	sb := &strings.Builder{}
+	r := func(i goyek.Input) goyek.Result {
+		i.Output.Write([]byte(msg)) //nolint:errcheck // not checking errors when writing to output
+		return goyek.Result{Status: goyek.StatusCanceled}
+	}
+	r = middleware.SilentNonFailed(r)
+
+	r(goyek.Input{Output: goyek.SyncWriter(sb)})
+
+	if !strings.Contains(sb.String(), msg) {
+		t.Errorf("got: %q; but should contain: %q", sb.String(), msg)
+	}
+}
+
 func TestSilentNonFailed_notFailed(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -0,0 +1,122 @@
+package goyek
+
+import "sync"
+
+// GetResourceLimit returns the number of parallel tasks
+// which can use the resource at the same time.
+// 1 is returned if the limit was not set.
+func GetResourceLimit(name string) int {
+	return DefaultFlow.ResourceLimit(name)
+}
+
+// ResourceLimit returns the number of parallel tasks
+// which can use the resource at the same time.
+// 1 is returned if the limit was not set.
+func (f *Flow) ResourceLimit(name string) int {
+	if n, ok := f.resourceLimits[name]; ok {
+		return n
+	}
+	return 1
+}
+
+// SetResourceLimit sets the number of parallel tasks
+// which can use the resource at the same time. See [Task.Resources].
+// It panics if n is less than 1.
+func SetResourceLimit(name string, n int) {
+	DefaultFlow.SetResourceLimit(name, n)
+}
+
+// SetResourceLimit sets the number of parallel tasks
+// which can use the resource at the same time. See [Task.Resources].
+// It panics if n is less than 1.
+func (f *Flow) SetResourceLimit(name string, n int) {
+	if name == "" {
+		panic("resource name cannot be empty")
+	}
+	if n < 1 {
+		panic("resource limit must be positive")
+	}
+	if f.resourceLimits == nil {
+		f.resourceLimits = map[string]int{}
+	}
+	f.resourceLimits[name] = n
+}
+
+// copyResources returns the copy of the resource names without duplicates.
+func copyResources(resources []string) []string {
+	var res []string
+	seen := map[string]bool{}
+	for _, name := range resources {
+		if name == "" {
+			panic("resource name cannot be empty")
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		res = append(res, name)
+	}
+	return res
+}
+
+// resourcePool limits the number of the tasks using the resources at once.
+type resourcePool struct {
+	limits map[string]int
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	used map[string]int
+}
+
+func newResourcePool(limits map[string]int) *resourcePool {
+	p := &resourcePool{
+		limits: make(map[string]int, len(limits)),
+		used:   map[string]int{},
+	}
+	for name, n := range limits {
+		p.limits[name] = n
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// acquire waits until all resources are available and takes them at once,
+// so that tasks waiting for each other's resources cannot deadlock.
+func (p *resourcePool) acquire(resources []string) {
+	if len(resources) == 0 {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for !p.available(resources) {
+		p.cond.Wait()
+	}
+	for _, name := range resources {
+		p.used[name]++
+	}
+}
+
+func (p *resourcePool) release(resources []string) {
+	if len(resources) == 0 {
+		return
+	}
+	p.mu.Lock()
+	for _, name := range resources {
+		p.used[name]--
+	}
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+func (p *resourcePool) available(resources []string) bool {
+	for _, name := range resources {
+		limit, ok := p.limits[name]
+		if !ok {
+			limit = 1
+		}
+		if p.used[name] >= limit {
+			return false
+		}
+	}
+	return true
+}
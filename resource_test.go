@@ -0,0 +1,92 @@
+package goyek_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestFlow_Resources(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	var active, overlaps int32
+	useDB := func(*goyek.A) {
+		if atomic.AddInt32(&active, 1) > 1 {
+			atomic.AddInt32(&overlaps, 1)
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&active, -1)
+	}
+	started := make(chan struct{})
+	var once sync.Once
+	for _, name := range []string{"one", "two", "three"} {
+		flow.Define(goyek.Task{
+			Name:      name,
+			Parallel:  true,
+			Resources: []string{"db", "db"},
+			Action: func(a *goyek.A) {
+				once.Do(func() { close(started) })
+				useDB(a)
+			},
+		})
+	}
+	flow.Define(goyek.Task{
+		Name:     "other",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			select {
+			case <-started:
+			case <-time.After(time.Second):
+				a.Error("should run concurrently with the tasks using resources")
+			}
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"one", "two", "three", "other"})
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, atomic.LoadInt32(&overlaps), int32(0), "should not overlap the tasks using the same resource")
+}
+
+func TestFlow_SetResourceLimit(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.SetResourceLimit("port", 2)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	action := func(a *goyek.A) {
+		wg.Done()
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			a.Error("should run both tasks at once")
+		}
+	}
+	flow.Define(goyek.Task{Name: "one", Parallel: true, Resources: []string{"port", "db"}, Action: action})
+	flow.Define(goyek.Task{Name: "two", Parallel: true, Resources: []string{"port"}, Action: action})
+	flow.Define(goyek.Task{Name: "three", Parallel: true, Resources: []string{"db", "port"}})
+
+	err := flow.Execute(context.Background(), []string{"one", "two", "three"})
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, flow.ResourceLimit("port"), 2, "should return the limit")
+	assertEqual(t, flow.ResourceLimit("db"), 1, "should return the default limit")
+}
+
+func TestFlow_SetResourceLimit_invalid(t *testing.T) {
+	flow := &goyek.Flow{}
+
+	assertPanics(t, func() { flow.SetResourceLimit("db", 0) }, "should panic for non-positive limit")
+	assertPanics(t, func() { flow.SetResourceLimit("", 1) }, "should panic for empty name")
+	assertPanics(t, func() { flow.Define(goyek.Task{Name: "task", Resources: []string{""}}) }, "should panic for empty resource name")
+}
@@ -0,0 +1,247 @@
+package goyek
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Resume is an option to skip the tasks which passed during the last
+// execution of the same tasks with the same options, so that a failed
+// or interrupted execution continues from the point of the failure.
+// The passed tasks are checkpointed in the state file set using
+// [Flow.SetStateFile] and the checkpoint is removed when the execution passes.
+//
+// The values set by the tasks using [A.SetValue] are not persisted.
+// Therefore, a passed task which set values is run again if any task
+// which is run depends on it, together with the passed tasks
+// in between.
+//
+// The checkpoint is discarded if the task names, usages, dependencies,
+// parallelism, or presence of actions or conditions have changed.
+// The changes of the actions' code cannot be detected.
+func Resume() Option {
+	return optionFunc(func(c *config) {
+		c.resume = true
+	})
+}
+
+// checkpoint contains the tasks which passed during the last execution.
+type checkpoint struct {
+	Key         string   `json:"key"`         // hash of the requested tasks and options
+	Fingerprint string   `json:"fingerprint"` // hash of the task definitions
+	Passed      []string `json:"passed"`
+	SetValues   []string `json:"set_values,omitempty"` // passed tasks which set values
+}
+
+// checkpointKey returns the hash of the requested tasks and options.
+func checkpointKey(tasks []string, cfg *config) string {
+	skipTasks := append([]string(nil), cfg.skipTasks...)
+	sort.Strings(skipTasks)
+	b, _ := json.Marshal(struct { //nolint:errchkjson // cannot fail
+		Tasks     []string
+		SkipTasks []string
+		NoDeps    bool
+	}{tasks, skipTasks, cfg.noDeps})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprint returns the hash of the task definitions.
+func (f *Flow) fingerprint() string {
+	h := sha256.New()
+	for _, task := range f.Tasks() {
+		deps := make([]string, 0, len(task.Deps()))
+		for _, dep := range task.Deps() {
+			deps = append(deps, dep.Name())
+		}
+		b, _ := json.Marshal(struct { //nolint:errchkjson // cannot fail
+			Name      string
+			Usage     string
+			Deps      []string
+			Parallel  bool
+			Action    bool
+			Condition bool
+		}{task.Name(), task.Usage(), deps, task.Parallel(), task.Action() != nil, task.Condition() != nil})
+		h.Write(b) //nolint:errcheck // hash never returns an error
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// stateRecorder saves the statuses of the run tasks
+// and the checkpoint in the state file.
+type stateRecorder struct {
+	flow *Flow
+
+	mu         sync.Mutex
+	statuses   map[string]Status
+	checkpoint *checkpoint
+	err        error // first error of saving the state
+}
+
+func newStateRecorder(f *Flow) *stateRecorder {
+	return &stateRecorder{
+		flow:     f,
+		statuses: map[string]Status{},
+	}
+}
+
+// start creates the checkpoint for the execution and returns the tasks
+// to skip, which passed before, if the execution is resumed.
+func (r *stateRecorder) start(tasks []string, cfg *config) ([]string, string, error) {
+	cp := &checkpoint{
+		Key:         checkpointKey(tasks, cfg),
+		Fingerprint: r.flow.fingerprint(),
+	}
+	r.checkpoint = cp
+	if !cfg.resume {
+		return nil, "", nil
+	}
+
+	state, err := r.flow.loadState()
+	if err != nil {
+		return nil, "", err
+	}
+	last := state.Checkpoint
+	switch {
+	case last == nil || last.Key != cp.Key:
+		return nil, "no checkpoint to resume", nil
+	case last.Fingerprint != cp.Fingerprint:
+		return nil, "task definitions changed, discarding checkpoint", nil
+	}
+	skipped := r.flow.resumeSkipped(tasks, cfg, last)
+	if len(skipped) == 0 {
+		return nil, "", nil
+	}
+	cp.Passed = skipped
+	for _, name := range last.SetValues {
+		if contains(skipped, name) {
+			cp.SetValues = append(cp.SetValues, name)
+		}
+	}
+	return skipped, "resuming, skipping passed tasks: " + strings.Join(skipped, ", "), nil
+}
+
+// resumeSkipped returns the passed tasks from the checkpoint which can be
+// skipped. The passed tasks which set values are not skipped if any task
+// which is run depends on them, as the values are not persisted.
+func (f *Flow) resumeSkipped(tasks []string, cfg *config, last *checkpoint) []string {
+	passed := map[string]bool{}
+	for _, name := range last.Passed {
+		passed[name] = true
+	}
+
+	// Collect the tasks of the execution.
+	var graph []string
+	visited := map[string]bool{}
+	for _, name := range cfg.skipTasks {
+		visited[name] = true
+	}
+	var visit func(t *taskSnapshot)
+	visit = func(t *taskSnapshot) {
+		if visited[t.name] {
+			return
+		}
+		visited[t.name] = true
+		graph = append(graph, t.name)
+		if !cfg.noDeps {
+			for _, dep := range t.deps {
+				visit(dep)
+			}
+		}
+	}
+	for _, name := range tasks {
+		if task, ok := f.tasks[name]; ok {
+			visit(task)
+		}
+	}
+
+	// Run the tasks which set values read by the tasks which are run,
+	// which in turn may read values set by other passed tasks.
+	// The skipped tasks hide their dependencies, so the passed tasks
+	// between them are run too.
+	deps := newValueStore(f.tasks)
+	for changed := true; changed; {
+		changed = false
+		for _, name := range graph {
+			if passed[name] {
+				continue
+			}
+			for _, provider := range last.SetValues {
+				if !passed[provider] || !deps.dependsOn(name, provider) {
+					continue
+				}
+				delete(passed, provider)
+				for _, between := range graph {
+					if passed[between] && deps.dependsOn(name, between) && deps.dependsOn(between, provider) {
+						delete(passed, between)
+					}
+				}
+				changed = true
+			}
+		}
+	}
+
+	var skipped []string
+	for _, name := range last.Passed {
+		if passed[name] {
+			skipped = append(skipped, name)
+		}
+	}
+	return skipped
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *stateRecorder) middleware(next Runner) Runner {
+	return func(in Input) Result {
+		res := next(in)
+		if _, ok := r.flow.tasks[in.TaskName]; !ok {
+			// Subtasks are not recorded.
+			return res
+		}
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.statuses[in.TaskName] = res.Status
+		if res.Status == StatusPassed {
+			r.checkpoint.Passed = append(r.checkpoint.Passed, in.TaskName)
+			if in.exec != nil && in.exec.values != nil && in.exec.values.hasValues(in.TaskName) {
+				r.checkpoint.SetValues = append(r.checkpoint.SetValues, in.TaskName)
+			}
+			r.save(r.checkpoint)
+		}
+		return res
+	}
+}
+
+// finish saves the state after the execution.
+// The checkpoint is removed if the execution passed.
+func (r *stateRecorder) finish(err error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		r.save(nil)
+	} else if len(r.statuses) > 0 {
+		r.save(r.checkpoint)
+	}
+	return r.err
+}
+
+func (r *stateRecorder) save(cp *checkpoint) {
+	if err := r.flow.saveState(r.statuses, cp); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+var errNoStateFile = errors.New("state file is required to rerun failed tasks or resume")
@@ -0,0 +1,144 @@
+package goyek_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestFlow_Resume(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	var got []string
+	fail := map[string]bool{"upload": true}
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetStateFile(stateFile)
+	action := func(a *goyek.A) {
+		got = append(got, a.Name())
+		if fail[a.Name()] {
+			a.Fail()
+		}
+	}
+	build := flow.Define(goyek.Task{Name: "build", Action: action})
+	sign := flow.Define(goyek.Task{Name: "sign", Action: action, Deps: goyek.Deps{build}})
+	upload := flow.Define(goyek.Task{Name: "upload", Action: action, Deps: goyek.Deps{sign}})
+	flow.Define(goyek.Task{Name: "tag", Action: action, Deps: goyek.Deps{upload}})
+
+	err := flow.Execute(context.Background(), []string{"tag"}, goyek.Resume())
+	assertFail(t, err, "should fail")
+	assertEqual(t, got, []string{"build", "sign", "upload"}, "should run tasks until the failure")
+	assertContains(t, out, "no checkpoint to resume", "should report no checkpoint")
+
+	got = nil
+	fail["upload"] = false
+	err = flow.Execute(context.Background(), []string{"tag"}, goyek.Resume())
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string{"upload", "tag"}, "should continue from the failure")
+	assertContains(t, out, "resuming, skipping passed tasks: build, sign", "should report the skipped tasks")
+
+	got = nil
+	err = flow.Execute(context.Background(), []string{"tag"}, goyek.Resume())
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string{"build", "sign", "upload", "tag"}, "should run all tasks after the passed execution")
+}
+
+func TestFlow_Resume_values(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	var got []string
+	failPublish := true
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetStateFile(stateFile)
+	build := flow.Define(goyek.Task{Name: "build", Action: func(a *goyek.A) {
+		got = append(got, a.Name())
+		a.SetValue("version", "v1.0.0")
+	}})
+	lint := flow.Define(goyek.Task{Name: "lint", Action: func(a *goyek.A) {
+		got = append(got, a.Name())
+		a.SetValue("issues", 0)
+	}})
+	test := flow.Define(goyek.Task{Name: "test", Deps: goyek.Deps{build}, Action: func(a *goyek.A) {
+		got = append(got, a.Name())
+	}})
+	vet := flow.Define(goyek.Task{Name: "vet", Action: func(a *goyek.A) {
+		got = append(got, a.Name())
+	}})
+	flow.Define(goyek.Task{Name: "publish", Deps: goyek.Deps{vet, lint, test}, Action: func(a *goyek.A) {
+		got = append(got, a.Name())
+		v, err := a.Value("build", "version")
+		if err != nil {
+			a.Fatal(err)
+		}
+		if failPublish {
+			a.Fatal("cannot publish ", v)
+		}
+	}})
+
+	err := flow.Execute(context.Background(), []string{"publish"}, goyek.Resume())
+	assertFail(t, err, "should fail")
+
+	got = nil
+	failPublish = false
+	err = flow.Execute(context.Background(), []string{"publish"}, goyek.Resume())
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string{"lint", "build", "test", "publish"}, "should run again the passed tasks which set values read by the resumed tasks")
+	assertContains(t, out, "resuming, skipping passed tasks: vet\n", "should skip the other passed tasks")
+}
+
+func TestFlow_Resume_otherExecution(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	var got []string
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetStateFile(stateFile)
+	build := flow.Define(goyek.Task{Name: "build", Action: func(a *goyek.A) { got = append(got, a.Name()) }})
+	flow.Define(goyek.Task{Name: "test", Action: func(a *goyek.A) { a.Fail() }, Deps: goyek.Deps{build}})
+
+	err := flow.Execute(context.Background(), []string{"test"})
+	assertFail(t, err, "should fail")
+
+	got = nil
+	err = flow.Execute(context.Background(), []string{"test"}, goyek.Resume(), goyek.NoDeps())
+	assertFail(t, err, "should fail")
+	err = flow.Execute(context.Background(), []string{"test"}, goyek.Resume())
+	assertFail(t, err, "should fail")
+	assertEqual(t, got, []string{"build"}, "should not resume the execution with other options")
+}
+
+func TestFlow_Resume_definitionsChanged(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	var got []string
+	action := func(a *goyek.A) { got = append(got, a.Name()) }
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetStateFile(stateFile)
+	build := flow.Define(goyek.Task{Name: "build", Action: action})
+	flow.Define(goyek.Task{Name: "test", Action: func(a *goyek.A) { a.Fail() }, Deps: goyek.Deps{build}})
+
+	err := flow.Execute(context.Background(), []string{"test"}, goyek.Resume())
+	assertFail(t, err, "should fail")
+
+	got = nil
+	build.SetUsage("build it")
+	err = flow.Execute(context.Background(), []string{"test"}, goyek.Resume())
+	assertFail(t, err, "should fail")
+	assertEqual(t, got, []string{"build"}, "should discard the checkpoint")
+	assertContains(t, out, "task definitions changed, discarding checkpoint", "should report the discarded checkpoint")
+}
+
+func TestFlow_Resume_noStateFile(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.Define(goyek.Task{Name: "task"})
+
+	err := flow.Execute(context.Background(), []string{"task"}, goyek.Resume())
+
+	assertInvalid(t, err, "should return error when the state file is not set")
+}
@@ -22,8 +22,11 @@ type (
 		// A nil Output means discard output. A non-nil Output must be safe for
 		// concurrent use. Use [SyncWriter] to adapt a writer that does not provide
 		// its own synchronization.
-		Output io.Writer
-		Logger Logger
+		Output   io.Writer
+		Logger   Logger
+		LogLevel LogLevel
+
+		exec *execState // set by the executor and A.Run
 	}
 
 	// Result of a task run.
@@ -31,6 +34,28 @@ type (
 		Status     Status
 		PanicValue interface{}
 		PanicStack []byte
+		// Attrs contains the attributes set using [A.SetAttr]
+		// in the order in which they were first set.
+		Attrs []Attr
+		// ExitCode is the exit code set using [A.SetExitCode].
+		ExitCode int
+	}
+
+	// Attr is a key-value attribute of a task run.
+	Attr struct {
+		Key   string
+		Value interface{}
+	}
+
+	// execState is the state of the flow execution passed to the task action.
+	// It is kept behind a pointer so that Input stays comparable.
+	execState struct {
+		values         *valueStore
+		matrix         map[string]string // set for subtasks of matrix tasks
+		secrets        []string          // values to mask in the task output
+		definedTask    string            // name of the defined task running the action
+		middlewares    []Middleware      // used to run subtasks
+		signalParallel func()            // called by A.Parallel in a subtask
 	}
 
 	// Middleware represents a task runner interceptor.
@@ -89,27 +114,65 @@ func (r taskRunner) run(in Input) Result {
 		out = io.Discard
 	}
 
+	exec := in.exec
+	if exec == nil {
+		exec = &execState{}
+	}
+	unmasked := out
+	var mask *maskWriter
+	if len(exec.secrets) > 0 {
+		// Mask the output before the middlewares can copy it elsewhere.
+		mask = newMaskWriter(out, exec.secrets)
+		defer mask.flush()
+		out = mask
+	}
+
 	logger := in.Logger
 	if logger == nil {
 		logger = FmtLogger{}
 	}
+	if l, ok := logger.(interface {
+		withTask(name string) Logger
+	}); ok {
+		// Loggers such as SlogLogger report the task name.
+		logger = l.withTask(in.TaskName)
+	}
+
+	definedTask := exec.definedTask
+	if definedTask == "" {
+		definedTask = in.TaskName
+	}
 
 	var failed, skipped bool
+	var exitCode int
 	a := &A{
 		mu:       &sync.Mutex{},
 		failed:   &failed,
 		skipped:  &skipped,
+		exitCode: &exitCode,
 		cleanups: &[]func(){},
+		attrs:    &[]Attr{},
 		name:     in.TaskName,
 		output:   out,
 		logger:   logger,
 		parallel: in.Parallel,
+		logLevel: in.LogLevel,
+		values:   exec.values,
+		matrix:   exec.matrix,
+		secrets:  exec.secrets,
+		unmasked: unmasked,
+		mask:     mask,
+
+		definedTask:    definedTask,
+		middlewares:    exec.middlewares,
+		signalParallel: exec.signalParallel,
+		subtasks:       &sync.WaitGroup{},
 	}
 	a = a.WithContext(ctx)
 
 	finished, panicVal, panicStack := a.run(r.action)
 
-	res := Result{}
+	res := Result{Attrs: a.Attrs(), ExitCode: a.ExitCode()}
 	switch {
 	case a.Failed():
 		res.Status = StatusFailed
@@ -122,5 +185,14 @@ func (r taskRunner) run(in Input) Result {
 		res.PanicValue = panicVal
 		res.PanicStack = panicStack
 	}
+	if res.Status == StatusFailed && res.PanicStack == nil {
+		// The failure is most likely caused by the interruption.
+		switch ctx.Err() {
+		case context.Canceled:
+			res.Status = StatusCanceled
+		case context.DeadlineExceeded:
+			res.Status = StatusTimedOut
+		}
+	}
 	return res
 }
@@ -1,6 +1,7 @@
 package goyek_test
 
 import (
+	"context"
 	"io"
 	"strings"
 	"sync"
@@ -51,6 +52,53 @@ func TestRunner(t *testing.T) {
 	}
 }
 
+func TestRunner_interrupted(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	timedOut, cancel := context.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	testCases := []struct {
+		desc   string
+		ctx    context.Context
+		want   goyek.Status
+		action func(*goyek.A)
+	}{
+		{
+			desc:   "canceled",
+			ctx:    canceled,
+			want:   goyek.StatusCanceled,
+			action: func(a *goyek.A) { a.Fatal(a.Context().Err()) },
+		},
+		{
+			desc:   "timed out",
+			ctx:    timedOut,
+			want:   goyek.StatusTimedOut,
+			action: func(a *goyek.A) { a.Fatal(a.Context().Err()) },
+		},
+		{
+			desc:   "canceled pass",
+			ctx:    canceled,
+			want:   goyek.StatusPassed,
+			action: func(*goyek.A) {},
+		},
+		{
+			desc:   "canceled panic",
+			ctx:    canceled,
+			want:   goyek.StatusFailed,
+			action: func(*goyek.A) { panic("panicked") },
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			r := goyek.NewRunner(tc.action)
+			got := r(goyek.Input{Context: tc.ctx})
+
+			assertEqual(t, got.Status, tc.want, "should return proper status")
+		})
+	}
+}
+
 func TestRunner_panic(t *testing.T) {
 	payload := "panicked"
 	r := goyek.NewRunner(func(*goyek.A) { panic(payload) })
@@ -98,3 +146,9 @@ func TestNewRunner_preservesOutput(t *testing.T) {
 		t.Fatal("NewRunner replaced Input.Output")
 	}
 }
+
+func TestInput_comparable(t *testing.T) {
+	in := goyek.Input{TaskName: "task"}
+
+	assertTrue(t, in == goyek.Input{TaskName: "task"}, "Input should be comparable")
+}
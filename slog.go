@@ -0,0 +1,189 @@
+//go:build go1.21
+// +build go1.21
+
+package goyek
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// Attribute keys used by [SlogLogger].
+const (
+	SlogTaskKey   = "task"
+	SlogStatusKey = "status"
+)
+
+// SlogLogger is a [Logger] which writes the messages as [log/slog] records.
+// Each record has the task name attribute and the source location
+// of the call to the logging method of [A] which respects [A.Helper].
+// The messages logged by [A.Error], [A.Fatal], [A.Skip], and their
+// formatting variants also have the status attribute.
+//
+// Create it using [NewSlogLogger].
+type SlogLogger struct {
+	newHandler func(w io.Writer) slog.Handler
+	lines      *CodeLineLogger // used for helper-aware frame skipping
+	task       string
+}
+
+// NewSlogLogger returns a SlogLogger which writes the records
+// using the handler returned by newHandler for the task output.
+// If newHandler is nil, [slog.NewTextHandler] with source locations
+// and all levels enabled is used.
+func NewSlogLogger(newHandler func(w io.Writer) slog.Handler) *SlogLogger {
+	if newHandler == nil {
+		newHandler = defaultSlogHandler
+	}
+	return &SlogLogger{
+		newHandler: newHandler,
+		lines:      &CodeLineLogger{},
+	}
+}
+
+func defaultSlogHandler(w io.Writer) slog.Handler {
+	return slog.NewTextHandler(w, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})
+}
+
+// withTask returns the logger for the task.
+func (l *SlogLogger) withTask(name string) Logger {
+	return &SlogLogger{newHandler: l.newHandler, lines: l.lines, task: name}
+}
+
+// Log is used by [A] logging functions.
+func (l *SlogLogger) Log(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelInfo, "", fmt.Sprint(args...))
+}
+
+// Logf is used by [A] logging functions.
+func (l *SlogLogger) Logf(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelInfo, "", fmt.Sprintf(format, args...))
+}
+
+// Debug is used by [A.Debug].
+func (l *SlogLogger) Debug(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelDebug, "", fmt.Sprint(args...))
+}
+
+// Debugf is used by [A.Debugf].
+func (l *SlogLogger) Debugf(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelDebug, "", fmt.Sprintf(format, args...))
+}
+
+// Info is used by [A.Info].
+func (l *SlogLogger) Info(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelInfo, "", fmt.Sprint(args...))
+}
+
+// Infof is used by [A.Infof].
+func (l *SlogLogger) Infof(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelInfo, "", fmt.Sprintf(format, args...))
+}
+
+// Warn is used by [A.Warn].
+func (l *SlogLogger) Warn(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelWarn, "", fmt.Sprint(args...))
+}
+
+// Warnf is used by [A.Warnf].
+func (l *SlogLogger) Warnf(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelWarn, "", fmt.Sprintf(format, args...))
+}
+
+// Error is used by [A.Error].
+func (l *SlogLogger) Error(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelError, StatusFailed.String(), fmt.Sprint(args...))
+}
+
+// Errorf is used by [A.Errorf].
+func (l *SlogLogger) Errorf(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelError, StatusFailed.String(), fmt.Sprintf(format, args...))
+}
+
+// Fatal is used by [A.Fatal].
+func (l *SlogLogger) Fatal(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelError, StatusFailed.String(), fmt.Sprint(args...))
+}
+
+// Fatalf is used by [A.Fatalf].
+func (l *SlogLogger) Fatalf(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelError, StatusFailed.String(), fmt.Sprintf(format, args...))
+}
+
+// Skip is used by [A.Skip].
+func (l *SlogLogger) Skip(w io.Writer, args ...interface{}) {
+	l.log(w, slog.LevelInfo, StatusSkipped.String(), fmt.Sprint(args...))
+}
+
+// Skipf is used by [A.Skipf].
+func (l *SlogLogger) Skipf(w io.Writer, format string, args ...interface{}) {
+	l.log(w, slog.LevelInfo, StatusSkipped.String(), fmt.Sprintf(format, args...))
+}
+
+// Helper marks the calling function as a helper function.
+// When reporting the source location, that function will be skipped.
+// Helper may be called simultaneously from multiple goroutines.
+func (l *SlogLogger) Helper() {
+	l.lines.Helper()
+}
+
+func (l *SlogLogger) log(w io.Writer, level slog.Level, status, msg string) {
+	ctx := context.Background()
+	h := l.newHandler(w)
+	if !h.Enabled(ctx, level) {
+		return
+	}
+	const skip = 2 // skip: SlogLogger.log + the logging method
+	frame := l.lines.frameSkip(skip)
+	r := slog.NewRecord(time.Now(), level, msg, frame.PC)
+	if l.task != "" {
+		r.AddAttrs(slog.String(SlogTaskKey, l.task))
+	}
+	if status != "" {
+		r.AddAttrs(slog.String(SlogStatusKey, status))
+	}
+	h.Handle(ctx, r) //nolint:errcheck // not checking errors when writing to output
+}
+
+// Slog returns a structured logger which writes to [A.Output].
+// The records have the task name attribute and the levels below
+// [A.LogLevel] are disabled.
+//
+// If the flow's logger is a [SlogLogger], its handler is used.
+// Otherwise, [slog.NewTextHandler] is used.
+func (a *A) Slog() *slog.Logger {
+	var h slog.Handler
+	if l, ok := a.logger.(*SlogLogger); ok {
+		h = l.newHandler(a.output)
+	} else {
+		h = slog.NewTextHandler(a.output, &slog.HandlerOptions{Level: slog.LevelDebug})
+	}
+	h = &slogLevelHandler{Handler: h, level: slogLevel(a.logLevel)}
+	return slog.New(h).With(SlogTaskKey, a.name)
+}
+
+// slogLevel maps the log level to slog. Their levels are scaled by 4.
+func slogLevel(level LogLevel) slog.Level {
+	return slog.Level(level * 4)
+}
+
+// slogLevelHandler disables the records below the level.
+type slogLevelHandler struct {
+	slog.Handler
+	level slog.Level
+}
+
+func (h *slogLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.Handler.Enabled(ctx, level)
+}
+
+func (h *slogLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+func (h *slogLevelHandler) WithGroup(name string) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
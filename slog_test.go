@@ -0,0 +1,86 @@
+//go:build go1.21
+// +build go1.21
+
+package goyek_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestSlogLogger(t *testing.T) {
+	flow := &goyek.Flow{}
+	out := &bytes.Buffer{}
+	flow.SetOutput(out)
+	flow.SetLogLevel(goyek.LevelDebug)
+	flow.SetLogger(goyek.NewSlogLogger(func(w io.Writer) slog.Handler {
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{AddSource: true, Level: slog.LevelDebug})
+	}))
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			a.Debug("debug")
+			slogHelper(a)
+			a.Slog().Warn("from slog", "key", "value")
+			a.Skip("skip")
+		},
+	})
+
+	_ = flow.Execute(context.Background(), []string{"task"})
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var r map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("invalid record %q: %v", line, err)
+		}
+		records = append(records, r)
+	}
+	requireEqual(t, len(records), 4, "number of records")
+
+	wantLines := []float64{30, 31, 32, 33}
+	wantLevels := []string{"DEBUG", "ERROR", "WARN", "INFO"}
+	wantStatuses := []interface{}{nil, "FAIL", nil, "SKIP"}
+	for i, r := range records {
+		assertEqual(t, r["task"], "task", "task attribute")
+		assertEqual(t, r["level"], wantLevels[i], "level")
+		assertEqual(t, r["status"], wantStatuses[i], "status attribute")
+		source, _ := r["source"].(map[string]interface{})
+		file, _ := source["file"].(string)
+		assertEqual(t, filepath.Base(file), "slog_test.go", "source file")
+		assertEqual(t, source["line"], wantLines[i], "source line")
+	}
+	assertEqual(t, records[2]["key"], "value", "slog attribute")
+}
+
+func slogHelper(a *goyek.A) {
+	a.Helper()
+	a.Error("error")
+}
+
+func TestA_Slog_level(t *testing.T) {
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetLogLevel(goyek.LevelWarn)
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			a.Slog().Info("hidden")
+			a.Slog().Warn("shown")
+		},
+	})
+
+	_ = flow.Execute(context.Background(), []string{"task"})
+
+	assertNotContains(t, out, "hidden", "should not log below the level")
+	assertContains(t, out, `level=WARN msg=shown task=task`, "should log at the level")
+}
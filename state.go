@@ -0,0 +1,139 @@
+package goyek
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// GetStateFile returns the path of the file in which the task statuses
+// are persisted. An empty string is returned if it was not set.
+func GetStateFile() string {
+	return DefaultFlow.StateFile()
+}
+
+// StateFile returns the path of the file in which the task statuses
+// are persisted. An empty string is returned if it was not set.
+func (f *Flow) StateFile() string {
+	return f.stateFile
+}
+
+// SetStateFile sets the path of the file in which [Flow.Execute]
+// persists the statuses of the run tasks, which are used by [RerunFailed].
+// The file keeps the status of the latest run of each task.
+// The state is not persisted if the path is empty.
+func SetStateFile(path string) {
+	DefaultFlow.SetStateFile(path)
+}
+
+// SetStateFile sets the path of the file in which [Flow.Execute]
+// persists the statuses of the run tasks, which are used by [RerunFailed].
+// The file keeps the status of the latest run of each task.
+// The state is not persisted if the path is empty.
+func (f *Flow) SetStateFile(path string) {
+	f.stateFile = path
+}
+
+// RerunFailed is an option to run only the tasks which failed
+// or have not been run according to the state file set using
+// [Flow.SetStateFile]. The tasks are selected from the provided tasks
+// and their dependencies. Tasks without an action are not selected
+// as they only group other tasks. The dependencies of the selected tasks
+// are processed like for other executions, unless [NoDeps] is used.
+func RerunFailed() Option {
+	return optionFunc(func(c *config) {
+		c.rerunFailed = true
+	})
+}
+
+// flowState is the content of the state file.
+type flowState struct {
+	Tasks      map[string]string `json:"tasks"` // task name -> status
+	Checkpoint *checkpoint       `json:"checkpoint,omitempty"`
+}
+
+func (f *Flow) loadState() (*flowState, error) {
+	state := &flowState{Tasks: map[string]string{}}
+	b, err := os.ReadFile(f.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, errors.New("invalid state file " + f.stateFile + ": " + err.Error())
+	}
+	if state.Tasks == nil {
+		state.Tasks = map[string]string{}
+	}
+	return state, nil
+}
+
+// saveState updates the state file with the statuses of the defined tasks
+// and replaces the checkpoint.
+func (f *Flow) saveState(statuses map[string]Status, cp *checkpoint) error {
+	state, err := f.loadState()
+	if err != nil {
+		// Overwrite the invalid state.
+		state = &flowState{Tasks: map[string]string{}}
+	}
+	for name, status := range statuses {
+		state.Tasks[name] = status.String()
+	}
+	state.Checkpoint = cp
+	b, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.stateFile), 0o750); err != nil {
+		return err
+	}
+	return os.WriteFile(f.stateFile, append(b, '\n'), 0o600)
+}
+
+// failedTasks returns the tasks to rerun selected from the provided tasks
+// and their dependencies. The dependencies are returned before the tasks
+// depending on them.
+func (f *Flow) failedTasks(tasks []string, cfg *config) ([]string, error) {
+	state, err := f.loadState()
+	if err != nil {
+		return nil, err
+	}
+
+	visited := map[string]bool{}
+	for _, name := range cfg.skipTasks {
+		visited[name] = true
+	}
+	var failed []string
+	var visit func(t *taskSnapshot)
+	visit = func(t *taskSnapshot) {
+		if visited[t.name] {
+			return
+		}
+		visited[t.name] = true
+		if !cfg.noDeps {
+			for _, dep := range t.deps {
+				visit(dep)
+			}
+		}
+		if t.action == nil {
+			return
+		}
+		switch state.Tasks[t.name] {
+		case StatusPassed.String(), StatusSkipped.String():
+			return
+		}
+		failed = append(failed, t.name)
+	}
+	for _, name := range tasks {
+		task, ok := f.tasks[name]
+		if !ok {
+			// Let the executor report the invalid input.
+			return tasks, nil
+		}
+		visit(task)
+	}
+	return failed, nil
+}
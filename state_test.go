@@ -0,0 +1,111 @@
+package goyek_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestFlow_RerunFailed(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "state", "goyek.json")
+	var got []string
+	fail := map[string]bool{"test": true}
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.SetStateFile(stateFile)
+	action := func(a *goyek.A) {
+		got = append(got, a.Name())
+		if fail[a.Name()] {
+			a.Fail()
+		}
+	}
+	build := flow.Define(goyek.Task{Name: "build", Action: action})
+	lint := flow.Define(goyek.Task{Name: "lint", Action: action})
+	test := flow.Define(goyek.Task{Name: "test", Action: action, Deps: goyek.Deps{build}})
+	flow.Define(goyek.Task{Name: "all", Deps: goyek.Deps{build, test, lint}})
+
+	err := flow.Execute(context.Background(), []string{"all"})
+	assertFail(t, err, "should fail")
+	assertEqual(t, got, []string{"build", "test"}, "should run tasks until the failure")
+	assertEqual(t, flow.StateFile(), stateFile, "should return the state file")
+
+	got = nil
+	fail["test"] = false
+	err = flow.Execute(context.Background(), []string{"all"}, goyek.RerunFailed())
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string{"build", "test", "lint"}, "should rerun the failed and not run tasks with dependencies")
+
+	got = nil
+	err = flow.Execute(context.Background(), []string{"all"}, goyek.RerunFailed())
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string(nil), "should not run any task")
+
+	b, err := os.ReadFile(stateFile) //nolint:gosec // test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertEqual(t, string(b), `{
+  "tasks": {
+    "build": "PASS",
+    "lint": "PASS",
+    "test": "PASS"
+  }
+}
+`, "should save the statuses of the defined tasks")
+}
+
+func TestFlow_RerunFailed_noDeps(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	writeFile(t, stateFile, `{"tasks":{"build":"PASS","test":"FAIL"}}`)
+	var got []string
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.SetStateFile(stateFile)
+	action := func(a *goyek.A) { got = append(got, a.Name()) }
+	build := flow.Define(goyek.Task{Name: "build", Action: action})
+	flow.Define(goyek.Task{Name: "test", Action: action, Deps: goyek.Deps{build}})
+
+	err := flow.Execute(context.Background(), []string{"test"}, goyek.RerunFailed(), goyek.NoDeps())
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string{"test"}, "should rerun only the failed task")
+}
+
+func TestFlow_RerunFailed_invalid(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.Define(goyek.Task{Name: "task"})
+
+	err := flow.Execute(context.Background(), []string{"task"}, goyek.RerunFailed())
+	assertInvalid(t, err, "should return error when the state file is not set")
+
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	writeFile(t, stateFile, "{")
+	flow.SetStateFile(stateFile)
+	err = flow.Execute(context.Background(), []string{"task"}, goyek.RerunFailed())
+	assertInvalid(t, err, "should return error when the state file is invalid")
+
+	err = flow.Execute(context.Background(), []string{"bad"}, goyek.RerunFailed())
+	assertInvalid(t, err, "should return error when the task is not defined")
+}
+
+func TestFlow_RerunFailed_notRun(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "goyek.json")
+	writeFile(t, stateFile, `{"tasks":{"build":"NOOP","test":"PASS"}}`)
+	var got []string
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.SetStateFile(stateFile)
+	action := func(a *goyek.A) { got = append(got, a.Name()) }
+	build := flow.Define(goyek.Task{Name: "build", Action: action})
+	flow.Define(goyek.Task{Name: "test", Action: action, Deps: goyek.Deps{build}})
+
+	err := flow.Execute(context.Background(), []string{"test"}, goyek.RerunFailed())
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, got, []string{"build"}, "should rerun the task whose action was not run, for example by a dry run")
+}
@@ -11,8 +11,16 @@ const (
 	StatusPassed
 	StatusFailed
 	StatusSkipped
+	StatusCanceled // failed because the task context was canceled
+	StatusTimedOut // failed because the task context deadline was exceeded
 )
 
+// Failed reports whether the status means that the task failed,
+// which is true for [StatusFailed], [StatusCanceled], and [StatusTimedOut].
+func (s Status) Failed() bool {
+	return s == StatusFailed || s == StatusCanceled || s == StatusTimedOut
+}
+
 func (s Status) String() string {
 	switch s {
 	case StatusNotRun:
@@ -23,6 +31,10 @@ func (s Status) String() string {
 		return "FAIL"
 	case StatusSkipped:
 		return "SKIP"
+	case StatusCanceled:
+		return "CANCEL"
+	case StatusTimedOut:
+		return "TIMEOUT"
 	}
 	return "goyek.Status(" + strconv.Itoa(int(s)) + ")"
 }
@@ -16,6 +16,8 @@ func TestStatus_String(t *testing.T) {
 		{name: "Passed", s: goyek.StatusPassed, want: "PASS"},
 		{name: "Failed", s: goyek.StatusFailed, want: "FAIL"},
 		{name: "Skipped", s: goyek.StatusSkipped, want: "SKIP"},
+		{name: "Canceled", s: goyek.StatusCanceled, want: "CANCEL"},
+		{name: "TimedOut", s: goyek.StatusTimedOut, want: "TIMEOUT"},
 		{name: "Other", s: goyek.Status(123), want: "goyek.Status(123)"},
 	}
 	for _, tc := range testCases {
@@ -26,3 +28,19 @@ func TestStatus_String(t *testing.T) {
 		})
 	}
 }
+
+func TestStatus_Failed(t *testing.T) {
+	failed := map[goyek.Status]bool{
+		goyek.StatusFailed:   true,
+		goyek.StatusCanceled: true,
+		goyek.StatusTimedOut: true,
+	}
+	for _, s := range []goyek.Status{
+		goyek.StatusNotRun, goyek.StatusPassed, goyek.StatusFailed,
+		goyek.StatusSkipped, goyek.StatusCanceled, goyek.StatusTimedOut,
+	} {
+		if got := s.Failed(); got != failed[s] {
+			t.Errorf("%v.Failed() = %v, want %v", s, got, failed[s])
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package goyek
+
+import "sync"
+
+// Run runs fn as a subtask of the running task called name
+// and reports whether fn succeeded.
+// The subtask is named "<task>/<name>", it is run by the same middlewares
+// as the task so it is reported separately, and has its own status,
+// timing, and cleanup functions.
+// If the subtask fails, the running task fails too.
+//
+// Run blocks until fn returns or calls [A.Parallel].
+// In the latter case, Run returns true and the subtask continues
+// concurrently. The running task waits for its parallel subtasks
+// before its cleanup functions are called.
+//
+// Run may be called simultaneously from multiple goroutines.
+func (a *A) Run(name string, fn func(a *A)) bool {
+	return a.runSubtask(name, fn, false)
+}
+
+// RunParallel runs fn as a subtask like [A.Run] which is run in parallel
+// with its parent task and other parallel subtasks from the start.
+// Unlike for [A.Parallel], the middlewares receive [Input.Parallel] set
+// to true, so that, for example, the output of the subtask is not mixed
+// with the output of other parallel subtasks.
+// RunParallel does not wait for the subtask to finish.
+func (a *A) RunParallel(name string, fn func(a *A)) {
+	a.runSubtask(name, fn, true)
+}
+
+func (a *A) runSubtask(name string, fn func(a *A), parallel bool) bool {
+	if name == "" {
+		panic("subtask name cannot be empty")
+	}
+	if fn == nil {
+		panic("nil subtask action")
+	}
+
+	if a.mask != nil {
+		// The subtask masks its own output, so keep the order of the output.
+		a.mask.flush()
+	}
+
+	runner := NewRunner(fn)
+	for _, m := range a.middlewares {
+		runner = m(runner)
+	}
+
+	var once sync.Once
+	signaled := make(chan struct{})
+	signal := func() {
+		once.Do(func() { close(signaled) })
+	}
+	if parallel {
+		signal()
+	}
+	in := Input{
+		Context:  a.ctx,
+		TaskName: a.name + "/" + name,
+		Parallel: a.parallel || parallel,
+		Output:   a.unmasked,
+		Logger:   a.logger,
+		LogLevel: a.logLevel,
+
+		exec: &execState{
+			values:         a.values,
+			matrix:         a.matrix,
+			secrets:        a.secrets,
+			definedTask:    a.definedTask,
+			middlewares:    a.middlewares,
+			signalParallel: signal,
+		},
+	}
+
+	done := make(chan Status, 1)
+	a.subtasks.Add(1)
+	go func() {
+		defer a.subtasks.Done()
+		res := runner(in)
+		if res.Status.Failed() {
+			a.mu.Lock()
+			if *a.exitCode == 0 {
+				*a.exitCode = res.ExitCode
+			}
+			a.mu.Unlock()
+			a.Fail()
+		}
+		done <- res.Status
+	}()
+
+	select {
+	case status := <-done:
+		return !status.Failed()
+	case <-signaled:
+		return true
+	}
+}
+
+// Parallel signals that the subtask is to be run in parallel with
+// its parent task and other parallel subtasks. See [A.Run].
+// Like in parallel tasks, [A.Setenv] and [A.Chdir] cannot be used
+// after calling Parallel.
+//
+// The middlewares have already started running the subtask, so they
+// still see [Input.Parallel] of the parent task. For example,
+// [github.com/goyek/goyek/v3/middleware.BufferParallel] does not buffer
+// the subtask output. Use [A.RunParallel] instead if this matters.
+//
+// Parallel panics if the running task is not a subtask.
+func (a *A) Parallel() {
+	if a.signalParallel == nil {
+		panic("Parallel called outside of a subtask")
+	}
+	a.parallel = true
+	a.signalParallel()
+}
+
+// waitSubtasks waits for the subtasks started by [A.Run].
+func (a *A) waitSubtasks() {
+	if a.subtasks != nil {
+		a.subtasks.Wait()
+	}
+}
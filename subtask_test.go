@@ -0,0 +1,146 @@
+package goyek_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/middleware"
+)
+
+func TestA_Run(t *testing.T) {
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.Use(middleware.ReportStatus)
+	var passed, failed bool
+	var steps []string
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			a.Cleanup(func() { steps = append(steps, "task cleanup") })
+			passed = a.Run("pass", func(a *goyek.A) {
+				a.Cleanup(func() { steps = append(steps, "pass cleanup") })
+				a.Log("from subtask")
+			})
+			failed = a.Run("fail", func(a *goyek.A) {
+				a.Run("nested", func(a *goyek.A) {
+					a.Fatal("nested failure")
+				})
+				steps = append(steps, "after nested")
+			})
+			steps = append(steps, "after subtasks")
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertFail(t, err, "should propagate subtask failure")
+	assertTrue(t, passed, "passed subtask should return true")
+	assertTrue(t, !failed, "failed subtask should return false")
+	assertEqual(t, steps, []string{"pass cleanup", "after nested", "after subtasks", "task cleanup"}, "steps")
+	assertContains(t, out, "===== TASK  task/pass\nfrom subtask\n----- PASS: task/pass", "should report subtask")
+	assertContains(t, out, "----- FAIL: task/fail/nested", "should report nested subtask")
+	assertContains(t, out, "----- FAIL: task/fail", "should report parent subtask")
+	assertContains(t, out, "----- FAIL: task (", "should report task")
+}
+
+func TestA_Parallel(t *testing.T) {
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	var mu sync.Mutex
+	var steps []string
+	step := func(s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		steps = append(steps, s)
+	}
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			a.Cleanup(func() { step("task cleanup") })
+			release := make(chan struct{})
+			for _, name := range []string{"a", "b"} {
+				a.Run(name, func(a *goyek.A) {
+					a.Parallel()
+					<-release // both subtasks must run concurrently
+					step("subtask")
+				})
+			}
+			close(release)
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, steps, []string{"subtask", "subtask", "task cleanup"}, "should wait for parallel subtasks before cleanup")
+}
+
+func TestA_Parallel_not_subtask(t *testing.T) {
+	res := goyek.NewRunner(func(a *goyek.A) {
+		a.Parallel()
+	})(goyek.Input{})
+
+	assertEqual(t, res.Status, goyek.StatusFailed, "should panic")
+}
+
+func TestA_RunParallel(t *testing.T) {
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.SetLogger(goyek.FmtLogger{})
+	flow.Use(middleware.ReportStatus, middleware.BufferParallel)
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			// The subtasks take turns to log so that their output is interleaved.
+			turns := map[string]chan struct{}{"a": make(chan struct{}), "b": make(chan struct{})}
+			next := map[string]string{"a": "b", "b": "a"}
+			for _, name := range []string{"a", "b"} {
+				name := name
+				a.RunParallel(name, func(a *goyek.A) {
+					for i := 0; i < 2; i++ {
+						<-turns[name]
+						a.Logf("%s %d", name, i)
+						if name == "a" || i == 0 {
+							turns[next[name]] <- struct{}{}
+						}
+					}
+				})
+			}
+			turns["a"] <- struct{}{}
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertPass(t, err, "should pass")
+	assertContains(t, out, "===== TASK  task/a\na 0\na 1\n----- PASS: task/a", "should buffer the subtask output")
+	assertContains(t, out, "===== TASK  task/b\nb 0\nb 1\n----- PASS: task/b", "should buffer the subtask output")
+}
+
+func TestA_Run_in_parallel_task(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	var steps []string
+	flow.Define(goyek.Task{
+		Name:     "task",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			a.Run("sub", func(a *goyek.A) {
+				steps = append(steps, "subtask")
+			})
+			steps = append(steps, "after subtask")
+		},
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+
+	assertPass(t, err, "should pass")
+	assertEqual(t, steps, []string{"subtask", "after subtask"}, "should wait for the subtask")
+}
@@ -1,5 +1,7 @@
 package goyek
 
+import "context"
+
 // Task represents a named task that can have action and dependencies.
 type Task struct {
 	// Name uniquely identifies the task.
@@ -20,6 +22,18 @@ type Task struct {
 	// Parallel marks that this task can be run in parallel
 	// with (and only with) other parallel tasks.
 	Parallel bool
+
+	// Condition is called with the task context before the action.
+	// If it returns false, the action is not called
+	// and the task is skipped with the returned reason.
+	// It is not called for a task without an action.
+	Condition func(ctx context.Context) (ok bool, reason string)
+
+	// Resources contains the names of the resources used by the task,
+	// such as a port or a database. Parallel tasks using the same resource
+	// do not overlap unless the resource limit set using
+	// [Flow.SetResourceLimit] allows it.
+	Resources []string
 }
 
 // DefinedTask represents a task that has been defined.
@@ -36,11 +50,13 @@ type Deps []*DefinedTask
 
 // taskSnapshot contains the mutable state owned by a Flow.
 type taskSnapshot struct {
-	name     string
-	usage    string
-	deps     []*taskSnapshot
-	action   func(a *A)
-	parallel bool
+	name      string
+	usage     string
+	deps      []*taskSnapshot
+	action    func(a *A)
+	parallel  bool
+	condition func(ctx context.Context) (bool, string)
+	resources []string
 }
 
 // Name returns the name of the task.
@@ -94,6 +110,38 @@ func (r *DefinedTask) Deps() Deps {
 	return deps
 }
 
+// Parallel reports whether the task can be run in parallel
+// with other parallel tasks.
+func (r *DefinedTask) Parallel() bool {
+	return r.parallel
+}
+
+// Condition returns the condition of the task.
+func (r *DefinedTask) Condition() func(ctx context.Context) (bool, string) {
+	return r.condition
+}
+
+// SetCondition sets the condition of the task. See [Task.Condition].
+func (r *DefinedTask) SetCondition(fn func(ctx context.Context) (bool, string)) {
+	r.mustBeDefined()
+	r.condition = fn
+}
+
+// Resources returns the names of the resources used by the task.
+func (r *DefinedTask) Resources() []string {
+	if len(r.resources) == 0 {
+		return nil
+	}
+	return append([]string(nil), r.resources...)
+}
+
+// SetResources sets the names of the resources used by the task.
+// See [Task.Resources].
+func (r *DefinedTask) SetResources(resources []string) {
+	r.mustBeDefined()
+	r.resources = copyResources(resources)
+}
+
 // SetDeps sets all task's dependencies.
 func (r *DefinedTask) SetDeps(deps Deps) {
 	r.mustBeDefined()
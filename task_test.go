@@ -120,6 +120,43 @@ func TestDefinedTask_SetAction(t *testing.T) {
 	assertTrue(t, newCalled, "should not call the new action")
 }
 
+func TestDefinedTask_Parallel(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.Define(goyek.Task{Name: "one", Parallel: true})
+
+	got := flow.Tasks()[0].Parallel()
+
+	assertTrue(t, got, "should return the parallel flag")
+}
+
+func TestDefinedTask_SetCondition(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	called := false
+	task := flow.Define(goyek.Task{Name: "one", Action: func(*goyek.A) { called = true }})
+
+	task.SetCondition(func(context.Context) (bool, string) { return false, "" })
+	err := flow.Execute(context.Background(), []string{"one"})
+
+	assertPass(t, err, "should pass")
+	assertTrue(t, flow.Tasks()[0].Condition() != nil, "should update the condition")
+	assertTrue(t, !called, "should not call the action")
+}
+
+func TestDefinedTask_SetResources(t *testing.T) {
+	flow := &goyek.Flow{}
+	task := flow.Define(goyek.Task{Name: "one", Resources: []string{"db"}})
+	assertEqual(t, task.Resources(), []string{"db"}, "should return the resources")
+
+	resources := []string{"port", "db", "port"}
+	task.SetResources(resources)
+	resources[0] = "changed"
+
+	assertEqual(t, flow.Tasks()[0].Resources(), []string{"port", "db"}, "should update the resources")
+	task.SetResources(nil)
+	assertEqual(t, task.Resources(), []string(nil), "should clear the resources")
+}
+
 func TestDefinedTask_SetDeps(t *testing.T) {
 	flow := &goyek.Flow{}
 	flow.SetOutput(io.Discard)
@@ -239,6 +276,18 @@ func TestDefinedTask_mutatorsRejectStaleHandle(t *testing.T) {
 				stale.SetDeps(nil)
 			},
 		},
+		{
+			name: "condition",
+			mutate: func(stale, _ *goyek.DefinedTask) {
+				stale.SetCondition(nil)
+			},
+		},
+		{
+			name: "resources",
+			mutate: func(stale, _ *goyek.DefinedTask) {
+				stale.SetResources([]string{"db"})
+			},
+		},
 	}
 
 	for _, tt := range tests {
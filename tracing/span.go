@@ -0,0 +1,202 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// String returns the hex encoded trace ID.
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanID identifies a span.
+type SpanID [8]byte
+
+// String returns the hex encoded span ID.
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// IsValid reports whether the span ID is not zero.
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+// Attribute is a key-value pair describing a span.
+// The value should be a string, bool, integer, or floating-point number.
+// Values of other types are exported using their default format.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// StatusCode is the status of a span.
+type StatusCode uint8
+
+// Status codes of a span.
+const (
+	StatusUnset StatusCode = iota
+	StatusOK
+	StatusError
+)
+
+// SpanData is a snapshot of an ended span passed to an [Exporter].
+type SpanData struct {
+	Name          string
+	TraceID       TraceID
+	SpanID        SpanID
+	ParentSpanID  SpanID
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	StatusCode    StatusCode
+	StatusMessage string
+}
+
+// Span represents a single operation within a trace.
+//
+// A Span is safe for concurrent use.
+// The methods of a nil Span are no-ops.
+type Span struct {
+	tracer *Tracer
+
+	mu    sync.Mutex
+	data  SpanData
+	ended bool
+}
+
+// Start creates a span which is a child of the span contained in ctx
+// and returns a context containing the created span.
+//
+// If ctx does not contain a span, a no-op nil span is returned.
+// The span must be ended by calling [Span.End].
+func Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	span := parent.tracer.newSpan(name, parent.TraceID(), parent.SpanID(), attrs)
+	return ContextWithSpan(ctx, span), span
+}
+
+// TraceID returns the ID of the trace the span belongs to.
+func (s *Span) TraceID() TraceID {
+	if s == nil {
+		return TraceID{}
+	}
+	return s.data.TraceID
+}
+
+// SpanID returns the ID of the span.
+func (s *Span) SpanID() SpanID {
+	if s == nil {
+		return SpanID{}
+	}
+	return s.data.SpanID
+}
+
+// Traceparent returns the value of the W3C Trace Context traceparent header
+// for the span. It can be passed to external programs, for example using
+// the TRACEPARENT environment variable, so that they can create nested spans.
+// An empty string is returned for a nil span.
+func (s *Span) Traceparent() string {
+	if s == nil {
+		return ""
+	}
+	return "00-" + s.data.TraceID.String() + "-" + s.data.SpanID.String() + "-01"
+}
+
+// SetAttributes sets attributes of the span.
+// Attributes with existing keys are overwritten.
+func (s *Span) SetAttributes(attrs ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, attr := range attrs {
+		s.setAttribute(attr)
+	}
+}
+
+func (s *Span) setAttribute(attr Attribute) {
+	for i := range s.data.Attributes {
+		if s.data.Attributes[i].Key == attr.Key {
+			s.data.Attributes[i].Value = attr.Value
+			return
+		}
+	}
+	s.data.Attributes = append(s.data.Attributes, attr)
+}
+
+// SetStatus sets the status of the span.
+// The message is used only for the [StatusError] code.
+func (s *Span) SetStatus(code StatusCode, msg string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data.StatusCode = code
+	s.data.StatusMessage = ""
+	if code == StatusError {
+		s.data.StatusMessage = msg
+	}
+}
+
+// End completes the span. Only the first call has an effect.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = time.Now()
+	data := s.data
+	data.Attributes = append([]Attribute(nil), s.data.Attributes...)
+	s.mu.Unlock()
+
+	s.tracer.ended(data)
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx containing the span.
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span contained in ctx
+// or nil if there is none.
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(spanKey{}).(*Span)
+	return span
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	_, _ = rand.Read(id[:])
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,164 @@
+// Package tracing provides OpenTelemetry-compatible tracing of flows and tasks.
+//
+// A [Tracer] creates a root span for each flow execution and a child span for
+// each task run. The task span is propagated through [goyek.A.Context],
+// so the code run by a task can create nested spans using [Start] and pass
+// [Span.Traceparent] to external programs.
+//
+// Ended spans are passed to a pluggable [Exporter] after each flow execution.
+// [NewFileExporter] writes them using the OTLP JSON encoding,
+// which works offline and can be loaded into tools such as Jaeger.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+// Attribute keys set by [Tracer].
+const (
+	AttrTasks        = "goyek.tasks"
+	AttrTaskName     = "goyek.task.name"
+	AttrTaskParallel = "goyek.task.parallel"
+	AttrTaskStatus   = "goyek.task.status"
+	AttrPanicValue   = "goyek.task.panic.value"
+	AttrPanicStack   = "goyek.task.panic.stack"
+)
+
+// Exporter exports ended spans.
+//
+// Export may be called simultaneously from multiple goroutines.
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+}
+
+// Tracer creates spans for flow executions and task runs.
+//
+// Use [Tracer.Executor] with [goyek.Flow.UseExecutor]
+// and [Tracer.Runner] with [goyek.Flow.Use].
+//
+// A Tracer is safe for concurrent use.
+type Tracer struct {
+	exporter Exporter
+
+	mu    sync.Mutex
+	spans []SpanData // ended spans to be exported
+}
+
+// NewTracer returns a Tracer which uses the exporter
+// to export the spans after each flow execution.
+func NewTracer(exporter Exporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+// Executor is a flow executor middleware which creates the root span
+// for the flow execution and exports the ended spans after it finishes.
+func (t *Tracer) Executor(next goyek.Executor) goyek.Executor {
+	return func(in goyek.ExecuteInput) error {
+		ctx := in.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		var span *Span
+		if parent := SpanFromContext(ctx); parent != nil {
+			span = t.newSpan("flow", parent.TraceID(), parent.SpanID(), nil)
+		} else {
+			span = t.newSpan("flow", newTraceID(), SpanID{}, nil)
+		}
+		span.SetAttributes(Attribute{Key: AttrTasks, Value: fmt.Sprint(in.Tasks)})
+		in.Context = ContextWithSpan(ctx, span)
+
+		err := next(in)
+
+		if err != nil {
+			span.SetStatus(StatusError, err.Error())
+		} else {
+			span.SetStatus(StatusOK, "")
+		}
+		span.End()
+
+		if exportErr := t.export(ctx); err == nil {
+			err = exportErr
+		}
+		return err
+	}
+}
+
+// Runner is a task runner middleware which creates a span for the task run.
+// The span is a child of the span contained in [goyek.Input.Context].
+func (t *Tracer) Runner(next goyek.Runner) goyek.Runner {
+	return func(in goyek.Input) goyek.Result {
+		ctx := in.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		attrs := []Attribute{
+			{Key: AttrTaskName, Value: in.TaskName},
+			{Key: AttrTaskParallel, Value: in.Parallel},
+		}
+		var span *Span
+		if parent := SpanFromContext(ctx); parent != nil {
+			span = t.newSpan(in.TaskName, parent.TraceID(), parent.SpanID(), attrs)
+		} else {
+			span = t.newSpan(in.TaskName, newTraceID(), SpanID{}, attrs)
+		}
+		in.Context = ContextWithSpan(ctx, span)
+
+		res := goyek.Result{Status: goyek.StatusFailed}
+		defer func() {
+			span.SetAttributes(Attribute{Key: AttrTaskStatus, Value: res.Status.String()})
+			if res.PanicStack != nil {
+				span.SetAttributes(
+					Attribute{Key: AttrPanicValue, Value: fmt.Sprint(res.PanicValue)},
+					Attribute{Key: AttrPanicStack, Value: string(res.PanicStack)},
+				)
+			}
+			if res.Status.Failed() {
+				span.SetStatus(StatusError, "task failed")
+			}
+			span.End()
+		}()
+		res = next(in)
+		return res
+	}
+}
+
+func (t *Tracer) newSpan(name string, traceID TraceID, parentID SpanID, attrs []Attribute) *Span {
+	span := &Span{
+		tracer: t,
+		data: SpanData{
+			Name:         name,
+			TraceID:      traceID,
+			SpanID:       newSpanID(),
+			ParentSpanID: parentID,
+			Start:        time.Now(),
+		},
+	}
+	for _, attr := range attrs {
+		span.setAttribute(attr)
+	}
+	return span
+}
+
+func (t *Tracer) ended(data SpanData) {
+	t.mu.Lock()
+	t.spans = append(t.spans, data)
+	t.mu.Unlock()
+}
+
+func (t *Tracer) export(ctx context.Context) error {
+	t.mu.Lock()
+	spans := t.spans
+	t.spans = nil
+	t.mu.Unlock()
+	if len(spans) == 0 || t.exporter == nil {
+		return nil
+	}
+	return t.exporter.Export(ctx, spans)
+}
@@ -0,0 +1,216 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+	"github.com/goyek/goyek/v3/tracing"
+)
+
+type exporterSpy struct {
+	mu    sync.Mutex
+	spans []tracing.SpanData
+	err   error
+}
+
+func (e *exporterSpy) Export(_ context.Context, spans []tracing.SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return e.err
+}
+
+func (e *exporterSpy) span(name string) tracing.SpanData {
+	for _, span := range e.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	return tracing.SpanData{}
+}
+
+func attr(span tracing.SpanData, key string) interface{} {
+	for _, a := range span.Attributes {
+		if a.Key == key {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	exporter := &exporterSpy{}
+	tracer := tracing.NewTracer(exporter)
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	flow.UseExecutor(tracer.Executor)
+	flow.Use(tracer.Runner)
+	var traceparent string
+	dep := flow.Define(goyek.Task{
+		Name:     "dep",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			_, span := tracing.Start(a.Context(), "nested", tracing.Attribute{Key: "key", Value: "value"})
+			traceparent = span.Traceparent()
+			span.End()
+		},
+	})
+	flow.Define(goyek.Task{
+		Name:   "task",
+		Deps:   goyek.Deps{dep},
+		Action: func(*goyek.A) { panic("oops") },
+	})
+
+	err := flow.Execute(context.Background(), []string{"task"})
+	if err == nil {
+		t.Fatal("should fail")
+	}
+
+	if len(exporter.spans) != 4 {
+		t.Fatalf("got %d spans, want 4", len(exporter.spans))
+	}
+	root := exporter.span("flow")
+	depSpan := exporter.span("dep")
+	nested := exporter.span("nested")
+	task := exporter.span("task")
+	if root.ParentSpanID.IsValid() {
+		t.Error("flow span should be the root span")
+	}
+	if root.StatusCode != tracing.StatusError {
+		t.Errorf("flow span should have error status, got %v", root.StatusCode)
+	}
+	for _, span := range []tracing.SpanData{depSpan, nested, task} {
+		if span.TraceID != root.TraceID {
+			t.Errorf("span %q should belong to the flow trace", span.Name)
+		}
+	}
+	if depSpan.ParentSpanID != root.SpanID || task.ParentSpanID != root.SpanID {
+		t.Error("task spans should be children of the flow span")
+	}
+	if nested.ParentSpanID != depSpan.SpanID {
+		t.Error("nested span should be a child of the task span")
+	}
+	if want := "00-" + nested.TraceID.String() + "-" + nested.SpanID.String() + "-01"; traceparent != want {
+		t.Errorf("got traceparent %q, want %q", traceparent, want)
+	}
+	if got := attr(depSpan, tracing.AttrTaskParallel); got != true {
+		t.Errorf("got parallel attribute %v, want true", got)
+	}
+	if got := attr(depSpan, tracing.AttrTaskStatus); got != "PASS" {
+		t.Errorf("got status attribute %v, want PASS", got)
+	}
+	if got := attr(task, tracing.AttrPanicValue); got != "oops" {
+		t.Errorf("got panic attribute %v, want oops", got)
+	}
+	if task.StatusCode != tracing.StatusError {
+		t.Errorf("failed task span should have error status, got %v", task.StatusCode)
+	}
+}
+
+func TestTracer_exportError(t *testing.T) {
+	exporter := &exporterSpy{err: errors.New("export failed")}
+	tracer := tracing.NewTracer(exporter)
+	executor := tracer.Executor(func(goyek.ExecuteInput) error { return nil })
+
+	if err := executor(goyek.ExecuteInput{}); err != exporter.err {
+		t.Errorf("got %v, want the export error", err)
+	}
+}
+
+func TestStart_noSpan(t *testing.T) {
+	ctx, span := tracing.Start(context.Background(), "span")
+
+	if span != nil {
+		t.Error("should return a nil span")
+	}
+	if tracing.SpanFromContext(ctx) != nil {
+		t.Error("should not add a span to the context")
+	}
+	// Methods of a nil span are no-ops.
+	span.SetAttributes(tracing.Attribute{Key: "key", Value: 1})
+	span.SetStatus(tracing.StatusError, "msg")
+	span.End()
+	if got := span.Traceparent(); got != "" {
+		t.Errorf("got traceparent %q, want empty", got)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	sb := &strings.Builder{}
+	tracer := tracing.NewTracer(tracing.NewFileExporter(sb))
+	runner := tracer.Executor(func(in goyek.ExecuteInput) error {
+		_, span := tracing.Start(in.Context, "span",
+			tracing.Attribute{Key: "int", Value: 42},
+			tracing.Attribute{Key: "float", Value: 1.5},
+			tracing.Attribute{Key: "bool", Value: false},
+		)
+		span.End()
+		return nil
+	})
+
+	if err := runner(goyek.ExecuteInput{Tasks: []string{"task"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Count(sb.String(), "\n") != 1 {
+		t.Errorf("should write a single line, got:\n%s", sb.String())
+	}
+	var got struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID      string `json:"traceId"`
+					SpanID       string `json:"spanId"`
+					ParentSpanID string `json:"parentSpanId"`
+					Name         string `json:"name"`
+					Attributes   []struct {
+						Key   string                 `json:"key"`
+						Value map[string]interface{} `json:"value"`
+					} `json:"attributes"`
+					Status struct {
+						Code int `json:"code"`
+					} `json:"status"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal([]byte(sb.String()), &got); err != nil {
+		t.Fatal(err)
+	}
+	spans := got.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	span, root := spans[0], spans[1]
+	if span.Name != "span" || root.Name != "flow" {
+		t.Fatalf("got spans %q and %q", span.Name, root.Name)
+	}
+	if len(span.TraceID) != 32 || len(span.SpanID) != 16 || span.ParentSpanID != root.SpanID {
+		t.Errorf("invalid span IDs: %+v", span)
+	}
+	if root.Status.Code != int(tracing.StatusOK) {
+		t.Errorf("got root status %d, want OK", root.Status.Code)
+	}
+	want := map[string]map[string]interface{}{
+		"int":   {"intValue": "42"},
+		"float": {"doubleValue": 1.5},
+		"bool":  {"boolValue": false},
+	}
+	for _, a := range span.Attributes {
+		if w := want[a.Key]; len(w) != 1 || len(a.Value) != 1 {
+			t.Errorf("unexpected attribute %q: %v", a.Key, a.Value)
+		} else {
+			for k, v := range w {
+				if a.Value[k] != v {
+					t.Errorf("got attribute %q: %v, want %v", a.Key, a.Value, w)
+				}
+			}
+		}
+	}
+}
@@ -18,7 +18,7 @@ func TestTrapTerminationSignalsDone(t *testing.T) {
 	var out strings.Builder
 	handlerDone := trapTerminationSignals(&out, signals, done, cancel, func(code int) {
 		exited <- code
-	})
+	}, &config{})
 
 	close(done)
 	waitForDone(t, handlerDone)
@@ -42,7 +42,7 @@ func TestTrapTerminationSignalsGraceful(t *testing.T) {
 	var out strings.Builder
 	handlerDone := trapTerminationSignals(&out, signals, done, cancel, func(code int) {
 		exited <- code
-	})
+	}, &config{})
 
 	signals <- os.Interrupt
 	waitForContext(t, ctx)
@@ -65,7 +65,7 @@ func TestTrapTerminationSignalsHardExit(t *testing.T) {
 	exitCode := -1
 	handlerDone := trapTerminationSignals(&out, signals, done, cancel, func(code int) {
 		exitCode = code
-	})
+	}, &config{})
 
 	signals <- os.Interrupt
 	waitForContext(t, ctx)
@@ -73,8 +73,8 @@ func TestTrapTerminationSignalsHardExit(t *testing.T) {
 	waitForDone(t, handlerDone)
 	close(done)
 
-	if exitCode != exitCodeFail {
-		t.Fatalf("got exit code %d, want %d", exitCode, exitCodeFail)
+	if exitCode != exitCodeInterrupted {
+		t.Fatalf("got exit code %d, want %d", exitCode, exitCodeInterrupted)
 	}
 	want := "first termination signal, graceful stop\nsecond termination signal, exit\n"
 	if got := out.String(); got != want {
@@ -109,3 +109,159 @@ func assertNoExit(t *testing.T, exited <-chan int) {
 	default:
 	}
 }
+
+func TestTrapTerminationSignalsGrace(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	signals := make(chan os.Signal, 1)
+	exited := make(chan int, 1)
+	var out strings.Builder
+	cfg := &config{}
+	InterruptGrace(10 * time.Millisecond).apply(cfg)
+	InterruptMessages("stop", "", "timeout").apply(cfg)
+	handlerDone := trapTerminationSignals(&out, signals, done, cancel, func(code int) {
+		exited <- code
+	}, cfg)
+
+	signals <- os.Interrupt
+	waitForContext(t, ctx)
+	waitForDone(t, handlerDone)
+
+	if code := <-exited; code != exitCodeInterrupted {
+		t.Fatalf("got exit code %d, want %d", code, exitCodeInterrupted)
+	}
+	if got, want := out.String(), "stop\ntimeout\n"; got != want {
+		t.Fatalf("got output %q, want %q", got, want)
+	}
+}
+
+func TestTrapTerminationSignalsHooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	exited := make(chan int, 1)
+	var out strings.Builder
+	var got []string
+	release := make(chan struct{})
+	cfg := &config{}
+	OnInterrupt(func(sig os.Signal) {
+		got = append(got, "first "+sig.String())
+	}).apply(cfg)
+	OnInterrupt(func(sig os.Signal) {
+		<-release
+		got = append(got, "second "+sig.String())
+	}).apply(cfg)
+	handlerDone := trapTerminationSignals(&out, signals, done, cancel, func(code int) {
+		exited <- code
+	}, cfg)
+
+	signals <- os.Interrupt
+	waitForContext(t, ctx)
+	close(done)
+	select {
+	case <-handlerDone:
+		t.Fatal("the handler should wait for the hooks")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	waitForDone(t, handlerDone)
+	assertNoExit(t, exited)
+
+	want := []string{"first interrupt", "second interrupt"}
+	if strings.Join(got, ";") != strings.Join(want, ";") {
+		t.Fatalf("got hook calls %q, want %q", got, want)
+	}
+}
+
+func TestTrapTerminationSignalsHooksHardExit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan struct{})
+	defer close(done)
+	signals := make(chan os.Signal, 1)
+	exited := make(chan int, 1)
+	var out strings.Builder
+	release := make(chan struct{})
+	defer close(release)
+	cfg := &config{}
+	OnInterrupt(func(os.Signal) { <-release }).apply(cfg)
+	handlerDone := trapTerminationSignals(&out, signals, done, cancel, func(code int) {
+		exited <- code
+	}, cfg)
+
+	signals <- os.Interrupt
+	waitForContext(t, ctx)
+	signals <- os.Interrupt
+	waitForDone(t, handlerDone)
+
+	if code := <-exited; code != exitCodeInterrupted {
+		t.Fatalf("got exit code %d, want %d", code, exitCodeInterrupted)
+	}
+}
+
+func TestOnInterruptNil(t *testing.T) {
+	defer func() {
+		if r := recover(); r != "nil interrupt hook" {
+			t.Fatalf("got panic %v", r)
+		}
+	}()
+	OnInterrupt(nil)
+}
+
+func TestDumpOnQuit(t *testing.T) {
+	running := newRunningTasks()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	runner := running.middleware(func(Input) Result {
+		close(started)
+		<-release
+		return Result{}
+	})
+	go runner(Input{TaskName: "task"})
+	<-started
+
+	done := make(chan struct{})
+	signals := make(chan os.Signal)
+	out := &strings.Builder{}
+	handlerDone := dumpOnQuit(SyncWriter(out), signals, done, running)
+	signals <- os.Interrupt
+	close(done)
+	waitForDone(t, handlerDone)
+	close(release)
+
+	got := out.String()
+	if want := "interrupt signal, running tasks: task\n"; !strings.HasPrefix(got, want) {
+		t.Fatalf("got output %q, should start with %q", got, want)
+	}
+	if want := "goroutine "; !strings.Contains(got, want) {
+		t.Fatalf("got output %q, should contain %q", got, want)
+	}
+}
+
+func TestRunningTasks(t *testing.T) {
+	running := newRunningTasks()
+	var got []string
+	runner := running.middleware(func(in Input) Result {
+		if in.TaskName == "b" {
+			got = running.list()
+		}
+		return Result{}
+	})
+	running.middleware(func(Input) Result {
+		runner(Input{TaskName: "b"})
+		return Result{}
+	})(Input{TaskName: "a"})
+
+	if want := "a,b"; strings.Join(got, ",") != want {
+		t.Fatalf("got running tasks %q, want %q", got, want)
+	}
+	if names := running.list(); len(names) != 0 {
+		t.Fatalf("got running tasks %q after the run, want none", names)
+	}
+}
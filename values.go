@@ -0,0 +1,95 @@
+package goyek
+
+import (
+	"errors"
+	"sync"
+)
+
+// valueStore contains the values set by the tasks during a flow execution.
+type valueStore struct {
+	defined map[string]*taskSnapshot
+
+	mu     sync.Mutex
+	values map[string]map[string]interface{} // task name -> key -> value
+}
+
+func newValueStore(defined map[string]*taskSnapshot) *valueStore {
+	return &valueStore{
+		defined: defined,
+		values:  map[string]map[string]interface{}{},
+	}
+}
+
+func (s *valueStore) set(task, key string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values[task] == nil {
+		s.values[task] = map[string]interface{}{}
+	}
+	s.values[task][key] = value
+}
+
+func (s *valueStore) get(task, key string) (interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, ok := s.values[task][key]
+	return v, ok
+}
+
+// hasValues reports whether the task has set any value.
+func (s *valueStore) hasValues(task string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.values[task]) > 0
+}
+
+// dependsOn reports whether the task transitively depends on the dependency.
+func (s *valueStore) dependsOn(task, dependency string) bool {
+	visited := map[*taskSnapshot]bool{}
+	var visit func(t *taskSnapshot) bool
+	visit = func(t *taskSnapshot) bool {
+		if t == nil || visited[t] {
+			return false
+		}
+		visited[t] = true
+		for _, dep := range t.deps {
+			if dep.name == dependency || visit(dep) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(s.defined[task])
+}
+
+// SetValue sets the value which can be read by the tasks depending on
+// the running task using [A.Value]. The values are available only
+// within the current flow execution.
+//
+// SetValue panics if the task is not run by [Flow.Execute].
+func (a *A) SetValue(key string, value interface{}) {
+	if a.values == nil {
+		panic("SetValue called outside of a flow execution")
+	}
+	a.values.set(a.definedTask, key, value)
+}
+
+// Value returns the value set using [A.SetValue] by the task
+// within the current flow execution.
+//
+// An error is returned if the task is not a dependency (direct or transitive)
+// of the running task, or if the value was not set, for example because
+// the task was skipped.
+func (a *A) Value(task, key string) (interface{}, error) {
+	if a.values == nil {
+		return nil, errors.New("value store not available outside of a flow execution")
+	}
+	if !a.values.dependsOn(a.definedTask, task) {
+		return nil, errors.New("task " + task + " is not a dependency of " + a.definedTask)
+	}
+	v, ok := a.values.get(task, key)
+	if !ok {
+		return nil, errors.New("value " + key + " not set by task " + task)
+	}
+	return v, nil
+}
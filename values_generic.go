@@ -0,0 +1,21 @@
+//go:build go1.21
+// +build go1.21
+
+package goyek
+
+import "fmt"
+
+// TaskValue returns the value set using [A.SetValue] by the task
+// like [A.Value] and checks that it has type T.
+func TaskValue[T any](a *A, task, key string) (T, error) {
+	var zero T
+	v, err := a.Value(task, key)
+	if err != nil {
+		return zero, err
+	}
+	t, ok := v.(T)
+	if !ok {
+		return zero, fmt.Errorf("value %s set by task %s has type %T, not %T", key, task, v, zero)
+	}
+	return t, nil
+}
@@ -0,0 +1,39 @@
+//go:build go1.21
+// +build go1.21
+
+package goyek_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestTaskValue(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	build := flow.Define(goyek.Task{
+		Name: "build",
+		Action: func(a *goyek.A) {
+			a.SetValue("version", "v1.0.0")
+		},
+	})
+	var version string
+	var err, typeErr error
+	flow.Define(goyek.Task{
+		Name: "publish",
+		Deps: goyek.Deps{build},
+		Action: func(a *goyek.A) {
+			version, err = goyek.TaskValue[string](a, "build", "version")
+			_, typeErr = goyek.TaskValue[int](a, "build", "version")
+		},
+	})
+
+	assertPass(t, flow.Execute(context.Background(), []string{"publish"}), "should pass")
+
+	assertPass(t, err, "should return value")
+	assertEqual(t, version, "v1.0.0", "value")
+	assertTrue(t, typeErr != nil, "should return error for different type")
+}
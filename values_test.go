@@ -0,0 +1,58 @@
+package goyek_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestA_Value(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(io.Discard)
+	run := 0
+	build := flow.Define(goyek.Task{
+		Name:     "build",
+		Parallel: true,
+		Action: func(a *goyek.A) {
+			run++
+			if run == 1 {
+				a.SetValue("version", "v1.0.0")
+			}
+		},
+	})
+	other := flow.Define(goyek.Task{Name: "other", Parallel: true, Action: func(*goyek.A) {}})
+	pkg := flow.Define(goyek.Task{Name: "package", Deps: goyek.Deps{build}, Action: func(*goyek.A) {}})
+	var got interface{}
+	var gotErr, notDepErr, notSetErr error
+	flow.Define(goyek.Task{
+		Name: "publish",
+		Deps: goyek.Deps{pkg, other},
+		Action: func(a *goyek.A) {
+			got, gotErr = a.Value("build", "version")
+			_, notDepErr = a.Value("lint", "version")
+			_, notSetErr = a.Value("other", "version")
+		},
+	})
+	flow.Define(goyek.Task{Name: "lint"})
+
+	assertPass(t, flow.Execute(context.Background(), []string{"publish"}), "first execution")
+
+	assertPass(t, gotErr, "should return value of transitive dependency")
+	assertEqual(t, got, "v1.0.0", "value")
+	assertTrue(t, notDepErr != nil, "should return error for task which is not a dependency")
+	assertTrue(t, notSetErr != nil, "should return error for value which is not set")
+
+	assertPass(t, flow.Execute(context.Background(), []string{"publish"}), "second execution")
+
+	assertTrue(t, gotErr != nil, "should reset values for each execution")
+}
+
+func TestA_SetValue_outside_execution(t *testing.T) {
+	res := goyek.NewRunner(func(a *goyek.A) {
+		a.SetValue("key", "value")
+	})(goyek.Input{})
+
+	assertEqual(t, res.Status, goyek.StatusFailed, "should panic")
+}
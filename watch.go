@@ -0,0 +1,249 @@
+package goyek
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	defaultWatchInterval = 500 * time.Millisecond
+	defaultWatchDebounce = 300 * time.Millisecond
+)
+
+// WatchInterval is an option for [Flow.Watch] to set how often
+// the files are checked for changes. The default is 500ms.
+func WatchInterval(d time.Duration) Option {
+	if d <= 0 {
+		panic("watch interval must be positive")
+	}
+	return optionFunc(func(c *config) {
+		c.watchInterval = d
+	})
+}
+
+// WatchDebounce is an option for [Flow.Watch] to set how long
+// there must be no further changes before the tasks are run again,
+// so that a burst of changes causes a single run. The default is 300ms.
+func WatchDebounce(d time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.watchDebounce = &d
+	})
+}
+
+// WatchExclude is an option for [Flow.Watch] to ignore the files
+// and directories whose base names match any of the patterns.
+// The pattern syntax is the one of [filepath.Match].
+// For example, use it to ignore the files created by the tasks.
+func WatchExclude(patterns ...string) Option {
+	for _, pattern := range patterns {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			panic("invalid watch exclude pattern: " + pattern)
+		}
+	}
+	return optionFunc(func(c *config) {
+		c.watchExclude = append(c.watchExclude, patterns...)
+	})
+}
+
+// Watch executes the tasks like [Flow.Execute] and executes them again
+// each time the files in the paths change. See [Flow.Watch].
+func Watch(ctx context.Context, tasks, paths []string, opts ...Option) error {
+	return DefaultFlow.Watch(ctx, tasks, paths, opts...)
+}
+
+// Watch executes the tasks like [Flow.Execute] and executes them again
+// each time the files in the paths change, until ctx is canceled.
+// The paths can be files or directories, which are watched recursively.
+// Hidden files and directories, whose names begin with a dot,
+// are not watched.
+//
+// The files are polled for changes. See [WatchInterval], [WatchDebounce],
+// and [WatchExclude] for the options to configure the watching.
+// When the files change during the execution, its context is canceled,
+// and the tasks are executed again after the execution finishes.
+// The failures of the tasks do not stop the watching.
+//
+// Watch returns the context error when ctx is canceled,
+// or other errors in case of invalid input.
+func (f *Flow) Watch(ctx context.Context, tasks, paths []string, opts ...Option) error {
+	if len(paths) == 0 {
+		return errors.New("no path to watch provided")
+	}
+	cfg := &config{}
+	for _, opt := range opts {
+		opt.apply(cfg)
+	}
+	interval := defaultWatchInterval
+	if cfg.watchInterval > 0 {
+		interval = cfg.watchInterval
+	}
+	debounce := defaultWatchDebounce
+	if cfg.watchDebounce != nil {
+		debounce = *cfg.watchDebounce
+	}
+
+	out := SyncWriter(f.Output())
+	originalOutput := f.output
+	f.output = out
+	defer func() {
+		f.output = originalOutput
+	}()
+
+	var (
+		runCancel context.CancelFunc
+		runDone   chan error
+	)
+	start := func() {
+		var runCtx context.Context
+		runCtx, runCancel = context.WithCancel(ctx)
+		runDone = make(chan error, 1)
+		done := runDone
+		go func() {
+			done <- f.Execute(runCtx, tasks, opts...)
+		}()
+	}
+	// finished handles the error returned by the execution.
+	finished := func(err error) error {
+		runCancel()
+		runDone = nil
+		var ferr *FailError
+		if err != nil && !errors.As(err, &ferr) &&
+			!errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+			return err
+		}
+		return nil
+	}
+
+	files := watchScan(paths, cfg.watchExclude)
+	start()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	var (
+		timer   *time.Timer
+		rerun   <-chan time.Time
+		pending bool
+	)
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-ctx.Done():
+			if runDone != nil {
+				finished(<-runDone) //nolint:errcheck // the watching is stopped
+			}
+			return ctx.Err()
+		case err := <-runDone:
+			if err := finished(err); err != nil {
+				return err
+			}
+			if pending {
+				start()
+				pending = false
+				continue
+			}
+			fmt.Fprintln(out, "watching for changes")
+		case <-ticker.C:
+			current := watchScan(paths, cfg.watchExclude)
+			changed := watchDiff(files, current)
+			files = current
+			if len(changed) == 0 {
+				continue
+			}
+			msg := "changes detected: " + changed[0]
+			if len(changed) > 1 {
+				msg += fmt.Sprintf(" and %d more", len(changed)-1)
+			}
+			fmt.Fprintln(out, msg)
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.NewTimer(debounce)
+			rerun = timer.C
+		case <-rerun:
+			rerun = nil
+			if runDone != nil {
+				// Interrupt the execution and run the tasks after it finishes.
+				runCancel()
+				pending = true
+				continue
+			}
+			start()
+		}
+	}
+}
+
+// watchedFile contains the file properties compared to detect changes.
+type watchedFile struct {
+	modTime int64
+	size    int64
+	mode    fs.FileMode
+}
+
+// watchScan returns the files in the paths.
+// The directories are not included as their changes
+// are detected by the changes of their files.
+func watchScan(paths, exclude []string) map[string]watchedFile {
+	files := map[string]watchedFile{}
+	for _, root := range paths {
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error { //nolint:errcheck // unreadable files are not watched
+			if err != nil {
+				return nil
+			}
+			if path != root && watchExcluded(d.Name(), exclude) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			files[path] = watchedFile{modTime: info.ModTime().UnixNano(), size: info.Size(), mode: info.Mode()}
+			return nil
+		})
+	}
+	return files
+}
+
+func watchExcluded(name string, exclude []string) bool {
+	if strings.HasPrefix(name, ".") {
+		return true
+	}
+	for _, pattern := range exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// watchDiff returns the sorted paths of the created, changed, and removed files.
+func watchDiff(before, after map[string]watchedFile) []string {
+	var changed []string
+	for path, file := range after {
+		if prev, ok := before[path]; !ok || prev != file {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,123 @@
+package goyek_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goyek/goyek/v3"
+)
+
+func TestFlow_Watch(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	writeFile(t, file, "1")
+
+	runs := make(chan int, 10)
+	count := 0
+	flow := &goyek.Flow{}
+	out := &strings.Builder{}
+	flow.SetOutput(out)
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(*goyek.A) {
+			count++
+			runs <- count
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- flow.Watch(ctx, []string{"task"}, []string{dir},
+			goyek.WatchInterval(10*time.Millisecond), goyek.WatchDebounce(10*time.Millisecond),
+			goyek.WatchExclude("*.log"))
+	}()
+
+	assertEqual(t, waitRun(t, runs), 1, "should run the task")
+	writeFile(t, filepath.Join(dir, "task.log"), "ignored")
+	writeFile(t, filepath.Join(dir, ".hidden"), "ignored")
+	writeFile(t, file, "22")
+	assertEqual(t, waitRun(t, runs), 2, "should run the task after the change")
+	cancel()
+
+	err := <-errCh
+	assertTrue(t, errors.Is(err, context.Canceled), "should return context canceled")
+	assertContains(t, out, "changes detected: "+file+"\n", "should report the changed file")
+	select {
+	case n := <-runs:
+		t.Fatalf("the excluded files should not cause run %d", n)
+	default:
+	}
+}
+
+func TestFlow_Watch_interrupt(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	writeFile(t, file, "1")
+
+	runs := make(chan int, 10)
+	count := 0
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.Define(goyek.Task{
+		Name: "task",
+		Action: func(a *goyek.A) {
+			count++
+			runs <- count
+			if count == 1 {
+				<-a.Context().Done()
+				a.Fatal(a.Context().Err())
+			}
+		},
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- flow.Watch(ctx, []string{"task"}, []string{file},
+			goyek.WatchInterval(10*time.Millisecond), goyek.WatchDebounce(0))
+	}()
+
+	assertEqual(t, waitRun(t, runs), 1, "should run the task")
+	writeFile(t, file, "22")
+	assertEqual(t, waitRun(t, runs), 2, "should interrupt the task and run it again")
+	cancel()
+
+	err := <-errCh
+	assertTrue(t, errors.Is(err, context.Canceled), "should return context canceled")
+}
+
+func TestFlow_Watch_invalid(t *testing.T) {
+	flow := &goyek.Flow{}
+	flow.SetOutput(&strings.Builder{})
+	flow.Define(goyek.Task{Name: "task"})
+
+	err := flow.Watch(context.Background(), []string{"bad"}, []string{t.TempDir()})
+	assertInvalid(t, err, "should return error for undefined task")
+
+	err = flow.Watch(context.Background(), []string{"task"}, nil)
+	assertInvalid(t, err, "should return error for no paths")
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitRun(t *testing.T, runs <-chan int) int {
+	t.Helper()
+	select {
+	case n := <-runs:
+		return n
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a task run")
+	}
+	return 0
+}